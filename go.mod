@@ -2,14 +2,17 @@ module lazylinear
 
 go 1.25.1
 
+require (
+	github.com/gdamore/tcell/v2 v2.8.1
+	github.com/machinebox/graphql v0.2.2
+	github.com/mattn/go-runewidth v0.0.16
+	go.starlark.net v0.0.0-20231121155337-90ade8b19d09
+	golang.org/x/sync v0.16.0
+)
+
 require (
 	github.com/gdamore/encoding v1.0.1 // indirect
-	github.com/gdamore/tcell/v2 v2.9.0 // indirect
-	github.com/jroimartin/gocui v0.5.0 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
-	github.com/machinebox/graphql v0.2.2 // indirect
-	github.com/mattn/go-runewidth v0.0.16 // indirect
-	github.com/nsf/termbox-go v1.1.1 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/rivo/uniseg v0.4.3 // indirect
 	golang.org/x/sys v0.35.0 // indirect
@@ -2,61 +2,394 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"runtime"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/machinebox/graphql"
 )
 
 // Client represents the Linear API client
 type Client struct {
-	client *graphql.Client
-	apiKey string
+	client       *graphql.Client
+	http         *http.Client
+	mu           sync.RWMutex // guards apiKey
+	apiKey       string
+	stateAliases map[string]string
+	log          *slog.Logger
 }
 
+// ErrUnauthorized is returned when Linear rejects the API key, or none is set
+var ErrUnauthorized = errors.New("API key invalid or missing")
+
+// authTransport turns 401 and 403 responses into ErrUnauthorized, which the
+// GraphQL client would otherwise report as a generic decoding failure
+type authTransport struct {
+	base http.RoundTripper
+}
+
+func (t authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		return nil, ErrUnauthorized
+	}
+	return resp, nil
+}
+
+// DefaultEndpoint is Linear's GraphQL API
+const DefaultEndpoint = "https://api.linear.app/graphql"
+
+// DefaultStates lists the canonical workflow states in display order
+var DefaultStates = []string{"In Review", "In Progress", "Blocked", "Todo", "Backlog"}
+
 // NewClient creates a new Linear API client
 func NewClient(apiKey string) *Client {
-	client := graphql.NewClient("https://api.linear.app/graphql")
-	client.Log = func(s string) { /* log.Println(s) */ } // Enable for debugging
+	// The default transport honors HTTP_PROXY, HTTPS_PROXY, and NO_PROXY
+	httpClient := &http.Client{Transport: authTransport{base: http.DefaultTransport}}
+	client := graphql.NewClient(DefaultEndpoint, graphql.WithHTTPClient(httpClient))
 
-	return &Client{
+	c := &Client{
 		client: client,
+		http:   httpClient,
 		apiKey: apiKey,
 	}
+	c.SetLogger(slog.New(slog.DiscardHandler))
+	return c
+}
+
+// TLSOptions customizes how the client verifies the API server, for networks
+// that intercept TLS with their own certificate authority
+type TLSOptions struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition
+	// to the system's
+	CAFile string
+}
+
+// ConfigureTLS applies opts to every subsequent request; proxies from the
+// environment are still honored
+func (c *Client) ConfigureTLS(opts TLSOptions) error {
+	tlsConfig := &tls.Config{}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = tlsConfig
+	c.http.Transport = authTransport{base: transport}
+	return nil
+}
+
+// SetEndpoint points the client at another GraphQL URL, such as a proxy,
+// an API gateway, or a mock server for integration tests
+func (c *Client) SetEndpoint(url string) {
+	c.client = graphql.NewClient(url, graphql.WithHTTPClient(c.http))
+	c.SetLogger(c.log)
+}
+
+// SetAPIKey replaces the API key used for subsequent requests
+func (c *Client) SetAPIKey(apiKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = apiKey
+}
+
+// SetLogger sends request timings, GraphQL errors, and the GraphQL client's
+// own request and response log to logger
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.log = logger
+	c.client.Log = func(s string) { logger.Debug("graphql", "message", s) }
+}
+
+// run executes req, logging how long it took under the calling method's name
+func (c *Client) run(ctx context.Context, req *graphql.Request, resp interface{}) error {
+	operation := "unknown"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		name := runtime.FuncForPC(pc).Name()
+		operation = name[strings.LastIndex(name, ".")+1:]
+	}
+	c.mu.RLock()
+	apiKey := c.apiKey
+	c.mu.RUnlock()
+	if apiKey == "" {
+		return ErrUnauthorized
+	}
+	req.Header.Set("Authorization", apiKey)
+	start := time.Now()
+	err := c.client.Run(ctx, req, resp)
+	if err != nil && !errors.Is(err, ErrUnauthorized) && strings.Contains(strings.ToLower(err.Error()), "authentication") {
+		// Linear reports a rejected key as a GraphQL authentication error
+		err = fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if err != nil {
+		c.log.Error("api request failed", "operation", operation, "duration", time.Since(start), "error", err)
+		return err
+	}
+	c.log.Debug("api request", "operation", operation, "duration", time.Since(start))
+	return nil
+}
+
+// SetStateAliases configures custom state names that map onto the
+// canonical DefaultStates
+func (c *Client) SetStateAliases(aliases map[string]string) {
+	c.stateAliases = aliases
+}
+
+// CanonicalState returns the canonical state name for a workflow state,
+// resolving any configured alias
+func (c *Client) CanonicalState(name string) string {
+	if canonical, ok := c.stateAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// stateNames returns the state names to request from the API, including aliases
+func (c *Client) stateNames() []string {
+	names := append([]string{}, DefaultStates...)
+	for alias := range c.stateAliases {
+		names = append(names, alias)
+	}
+	return names
 }
 
 // Issue represents a Linear issue
 type Issue struct {
-	ID          string `json:"id"`
-	Identifier  string `json:"identifier"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	URL         string `json:"url"`
-	BranchName  string `json:"branchName"`
-	State       struct {
+	ID          string  `json:"id"`
+	Identifier  string  `json:"identifier"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	URL         string  `json:"url"`
+	BranchName  string  `json:"branchName"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+	StartedAt   string  `json:"startedAt"`
+	CompletedAt string  `json:"completedAt"`
+	CanceledAt  string  `json:"canceledAt"`
+	Priority    float64 `json:"priority"`
+	DueDate     string  `json:"dueDate"`
+	// SLABreachesAt is when the issue's SLA is breached, if one applies
+	SLABreachesAt string   `json:"slaBreachesAt"`
+	Estimate      *float64 `json:"estimate"`
+	Labels        struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Project struct {
+		Name string `json:"name"`
+		// Progress is the share of the project's scope completed, from 0 to 1
+		Progress float64 `json:"progress"`
+	} `json:"project"`
+	Team struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"team"`
+	State struct {
+		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"state"`
 	Assignee struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"assignee"`
+	Subscribers struct {
+		Nodes []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"subscribers"`
 	Comments struct {
 		Nodes []Comment `json:"nodes"`
 	} `json:"comments"`
+	// Parent is set on sub-issues
+	Parent *struct {
+		ID string `json:"id"`
+	} `json:"parent"`
+	Children struct {
+		Nodes []struct {
+			State struct {
+				Type string `json:"type"`
+			} `json:"state"`
+		} `json:"nodes"`
+	} `json:"children"`
+	Attachments struct {
+		Nodes []Attachment `json:"nodes"`
+	} `json:"attachments"`
+	Relations struct {
+		Nodes []Relation `json:"nodes"`
+	} `json:"relations"`
+	InverseRelations struct {
+		Nodes []Relation `json:"nodes"`
+	} `json:"inverseRelations"`
+	// Detailed reports whether the description, subscribers, comments,
+	// attachments, and relations were loaded; lists leave them empty
+	Detailed bool `json:"-"`
+}
+
+// RelatedIssue is the minimal view of an issue on the other side of a relation
+type RelatedIssue struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	State      struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"state"`
+}
+
+// Resolved reports whether the issue is completed or canceled
+func (r RelatedIssue) Resolved() bool {
+	return r.State.Type == "completed" || r.State.Type == "canceled"
+}
+
+// Relation links two issues, e.g. "blocks", "related", or "duplicate"
+type Relation struct {
+	Type         string       `json:"type"`
+	Issue        RelatedIssue `json:"issue"`
+	RelatedIssue RelatedIssue `json:"relatedIssue"`
+}
+
+// PriorityLabel returns the name of the issue's priority, or "" for none
+func (i Issue) PriorityLabel() string {
+	switch int(i.Priority) {
+	case 1:
+		return "Urgent"
+	case 2:
+		return "High"
+	case 3:
+		return "Medium"
+	case 4:
+		return "Low"
+	}
+	return ""
+}
+
+// Progress returns how many sub-issues are completed out of those not
+// canceled; total is zero for issues without sub-issues
+func (i Issue) Progress() (done, total int) {
+	for _, child := range i.Children.Nodes {
+		switch child.State.Type {
+		case "canceled":
+			continue
+		case "completed":
+			done++
+		}
+		total++
+	}
+	return done, total
+}
+
+// Blockers returns the unresolved issues blocking this one
+func (i Issue) Blockers() []RelatedIssue {
+	var blockers []RelatedIssue
+	for _, relation := range i.InverseRelations.Nodes {
+		if relation.Type == "blocks" && !relation.Issue.Resolved() {
+			blockers = append(blockers, relation.Issue)
+		}
+	}
+	return blockers
+}
+
+// Attachment is a link attached to an issue, such as a GitHub pull request,
+// Figma file, or Slack thread
+type Attachment struct {
+	Title      string                 `json:"title"`
+	Subtitle   string                 `json:"subtitle"`
+	URL        string                 `json:"url"`
+	SourceType string                 `json:"sourceType"`
+	Metadata   map[string]interface{} `json:"metadata"`
+}
+
+// Status returns the state reported by the attachment's integration, e.g.
+// "open" or "merged" for pull requests, or "" when it has none
+func (a Attachment) Status() string {
+	if status, ok := a.Metadata["status"].(string); ok {
+		return status
+	}
+	return ""
 }
 
 // Comment represents a comment on an issue
 type Comment struct {
+	ID        string `json:"id"`
 	Body      string `json:"body"`
 	CreatedAt string `json:"createdAt"`
+	URL       string `json:"url"`
 	User      struct {
+		ID   string `json:"id"`
 		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"user"`
+	Reactions []Reaction `json:"reactions"`
+	// Parent is set on replies to the thread's root comment
+	Parent *struct {
+		ID string `json:"id"`
+	} `json:"parent"`
+}
+
+// Reaction is an emoji reaction left on a comment
+type Reaction struct {
+	ID    string `json:"id"`
+	Emoji string `json:"emoji"`
+	User  struct {
+		ID string `json:"id"`
 	} `json:"user"`
 }
 
 // Viewer represents the current user
 type Viewer struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Admin       bool   `json:"admin"`
+	Guest       bool   `json:"guest"`
+	Teams       struct {
+		Nodes []Team `json:"nodes"`
+	} `json:"teams"`
+}
+
+// IsMember reports whether the viewer belongs to the given team
+func (v *Viewer) IsMember(teamID string) bool {
+	for _, team := range v.Teams.Nodes {
+		if team.ID == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// User represents a Linear user
+type User struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Active      bool   `json:"active"`
+	URL         string `json:"url"`
 }
 
 // Team represents a Linear team
@@ -73,20 +406,27 @@ func (c *Client) GetViewer(ctx context.Context) (*Viewer, error) {
 			viewer {
 				id
 				name
+				displayName
+				admin
+				guest
+				teams {
+					nodes {
+						id
+						name
+						key
+					}
+				}
 			}
 		}
 	`)
 
 	// Set authorization header
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", c.apiKey)
-	}
 
 	var resp struct {
 		Viewer Viewer `json:"viewer"`
 	}
 
-	if err := c.client.Run(ctx, req, &resp); err != nil {
+	if err := c.run(ctx, req, &resp); err != nil {
 		return nil, err
 	}
 
@@ -107,97 +447,187 @@ func (c *Client) GetTeams(ctx context.Context) ([]Team, error) {
 		}
 	`)
 
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", c.apiKey)
-	}
-
 	var resp struct {
 		Teams struct {
 			Nodes []Team `json:"nodes"`
 		} `json:"teams"`
 	}
 
-	if err := c.client.Run(ctx, req, &resp); err != nil {
+	if err := c.run(ctx, req, &resp); err != nil {
 		return nil, err
 	}
 
 	return resp.Teams.Nodes, nil
 }
 
-// GetIssues fetches issues from Linear filtered by specified states
-func (c *Client) GetIssues(ctx context.Context, teamID string) ([]Issue, error) {
-	var query string
-	if teamID != "" {
-		query = `
-		query($teamID: ID!) {
-			issues(filter: {
-				team: { id: { eq: $teamID } }
-				state: {
-					name: {
-						in: ["In Review", "In Progress", "Blocked", "Todo", "Backlog"]
-					}
-				}
-			}) {
-				nodes {
+// listFields is the field selection of issue lists: what the list rows,
+// filters, and alerts need, leaving the heavy fields to detailFields. Of
+// the nested fields, labels feed the label column, filter, and search;
+// project the project column and per-project repos; children the sub-issue
+// progress bar; and inverseRelations only the blocker states the startable
+// filter checks, with the related issues themselves left to detailFields.
+const listFields = `
 					id
 					identifier
 					title
-					description
 					url
 					branchName
+					createdAt
+					updatedAt
+					startedAt
+					completedAt
+					canceledAt
+					priority
+					estimate
+					dueDate
+					slaBreachesAt
+					labels {
+						nodes {
+							name
+						}
+					}
+					project {
+						name
+						progress
+					}
+					team {
+						id
+						key
+					}
 					state {
+						id
 						name
 					}
 					assignee {
 						id
 						name
 					}
+					parent {
+						id
+					}
+					children {
+						nodes {
+							state {
+								type
+							}
+						}
+					}
+					inverseRelations {
+						nodes {
+							type
+							issue {
+								state {
+									type
+								}
+							}
+						}
+					}
+`
+
+// detailFields are the fields only the details pane needs
+const detailFields = `
+					description
+					subscribers {
+						nodes {
+							id
+							name
+						}
+					}
 					comments {
 						nodes {
+							id
 							body
 							createdAt
+							url
 							user {
+								id
 								name
+								url
+							}
+							reactions {
+								id
+								emoji
+								user {
+									id
+								}
+							}
+							parent {
+								id
+							}
+						}
+					}
+					attachments {
+						nodes {
+							title
+							subtitle
+							url
+							sourceType
+							metadata
+						}
+					}
+					inverseRelations {
+						nodes {
+							issue {
+								id
+								identifier
+								title
+								state {
+									name
+								}
+							}
+						}
+					}
+					relations {
+						nodes {
+							type
+							relatedIssue {
+								id
+								identifier
+								title
+								state {
+									name
+									type
+								}
 							}
 						}
 					}
+`
+
+// issueFields is the full field selection of single-issue queries
+const issueFields = listFields + detailFields
+
+// GetIssues fetches issues from Linear filtered by specified states
+func (c *Client) GetIssues(ctx context.Context, teamID string) ([]Issue, error) {
+	var query string
+	if teamID != "" {
+		query = `
+		query($teamID: ID!, $states: [String!]) {
+			issues(filter: {
+				team: { id: { eq: $teamID } }
+				state: {
+					name: {
+						in: $states
+					}
+				}
+			}) {
+				nodes {
+					` + listFields + `
 				}
 			}
 		}
 		`
 	} else {
 		query = `
-		query {
+		query($states: [String!]) {
 			issues(filter: {
 				state: {
 					name: {
-						in: ["In Review", "In Progress", "Blocked", "Todo", "Backlog"]
+						in: $states
 					}
 				}
 			}) {
 				nodes {
-					id
-					identifier
-					title
-					description
-					url
-					branchName
-					state {
-						name
-					}
-					assignee {
-						id
-						name
-					}
-					comments {
-						nodes {
-							body
-							createdAt
-							user {
-								name
-							}
-						}
-					}
+					` + listFields + `
 				}
 			}
 		}
@@ -209,11 +639,9 @@ func (c *Client) GetIssues(ctx context.Context, teamID string) ([]Issue, error)
 	if teamID != "" {
 		req.Var("teamID", teamID)
 	}
+	req.Var("states", c.stateNames())
 
 	// Set authorization header
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", c.apiKey)
-	}
 
 	var resp struct {
 		Issues struct {
@@ -221,23 +649,142 @@ func (c *Client) GetIssues(ctx context.Context, teamID string) ([]Issue, error)
 		} `json:"issues"`
 	}
 
-	if err := c.client.Run(ctx, req, &resp); err != nil {
+	if err := c.run(ctx, req, &resp); err != nil {
 		return nil, err
 	}
 
 	issues := resp.Issues.Nodes
+	c.SortByState(issues)
+	return issues, nil
+}
+
+// issuePageSize is how many issues each page of a paginated query holds
+const issuePageSize = 100
+
+// IssueQuery narrows GetAllIssues; the API applies every field, and empty
+// fields match all issues
+type IssueQuery struct {
+	// TeamID limits the issues to one team
+	TeamID string
+	// AssigneeID limits the issues to one assignee
+	AssigneeID string
+	// States are the workflow state names to match, by default the tracked
+	// states and their aliases
+	States []string
+	// Search matches titles containing it, ignoring case
+	Search string
+}
+
+// GetAllIssues fetches every issue matching q. Unlike GetIssues it follows
+// the pages past the first, so it suits callers that need the whole
+// workspace.
+func (c *Client) GetAllIssues(ctx context.Context, q IssueQuery) ([]Issue, error) {
+	states := q.States
+	if len(states) == 0 {
+		states = c.stateNames()
+	}
+	filter := map[string]interface{}{
+		"state": map[string]interface{}{"name": map[string]interface{}{"in": states}},
+	}
+	if q.TeamID != "" {
+		filter["team"] = map[string]interface{}{"id": map[string]interface{}{"eq": q.TeamID}}
+	}
+	if q.AssigneeID != "" {
+		filter["assignee"] = map[string]interface{}{"id": map[string]interface{}{"eq": q.AssigneeID}}
+	}
+	if q.Search != "" {
+		filter["title"] = map[string]interface{}{"containsIgnoreCase": q.Search}
+	}
+	issues, err := c.issuePages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	c.SortByState(issues)
+	return issues, nil
+}
+
+// GetTeamIssues fetches every issue in the tracked states of the teams
+// matching teamRef by key, name, or ID, so the issues can load before the
+// team list resolves the reference
+func (c *Client) GetTeamIssues(ctx context.Context, teamRef string) ([]Issue, error) {
+	filter := map[string]interface{}{
+		"state": map[string]interface{}{"name": map[string]interface{}{"in": c.stateNames()}},
+		"team": map[string]interface{}{"or": []interface{}{
+			map[string]interface{}{"key": map[string]interface{}{"eqIgnoreCase": teamRef}},
+			map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": teamRef}},
+			map[string]interface{}{"id": map[string]interface{}{"eq": teamRef}},
+		}},
+	}
+	issues, err := c.issuePages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	c.SortByState(issues)
+	return issues, nil
+}
+
+// pageInfo tells whether a connection has more pages, and where the next
+// one starts
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// issuePages fetches the issues matching an IssueFilter, page by page until
+// the last
+func (c *Client) issuePages(ctx context.Context, filter map[string]interface{}) ([]Issue, error) {
+	var issues []Issue
+	var after *string
+	for {
+		req := graphql.NewRequest(`
+			query($filter: IssueFilter, $first: Int, $after: String) {
+				issues(filter: $filter, first: $first, after: $after) {
+					nodes {
+						` + listFields + `
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		`)
 
-	stateOrder := map[string]int{
-		"In Review":   0,
-		"In Progress": 1,
-		"Blocked":     2,
-		"Todo":        3,
-		"Backlog":     4,
+		req.Var("filter", filter)
+		req.Var("first", issuePageSize)
+		req.Var("after", after)
+
+		var resp struct {
+			Issues struct {
+				Nodes    []Issue  `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"issues"`
+		}
+
+		if err := c.run(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, resp.Issues.Nodes...)
+		page := resp.Issues.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return issues, nil
+		}
+		after = &page.EndCursor
+	}
+}
+
+// SortByState orders issues by their canonical state in DefaultStates order,
+// with other states last
+func (c *Client) SortByState(issues []Issue) {
+	stateOrder := make(map[string]int, len(DefaultStates))
+	for i, state := range DefaultStates {
+		stateOrder[state] = i
 	}
 
 	sort.SliceStable(issues, func(i, j int) bool {
-		orderI, okI := stateOrder[issues[i].State.Name]
-		orderJ, okJ := stateOrder[issues[j].State.Name]
+		orderI, okI := stateOrder[c.CanonicalState(issues[i].State.Name)]
+		orderJ, okJ := stateOrder[c.CanonicalState(issues[j].State.Name)]
 
 		if !okI {
 			orderI = 999
@@ -248,42 +795,1297 @@ func (c *Client) GetIssues(ctx context.Context, teamID string) ([]Issue, error)
 
 		return orderI < orderJ
 	})
+}
 
-	return issues, nil
+// Startup is everything the TUI needs for its first screen
+type Startup struct {
+	Viewer Viewer
+	Teams  []Team
+	Issues []Issue
 }
 
-// AddComment adds a comment to an issue
-func (c *Client) AddComment(ctx context.Context, issueID string, body string) error {
+// GetStartup fetches the viewer, all teams, and the listed issues of the
+// team matching teamRef by key, name, or ID in a single request
+func (c *Client) GetStartup(ctx context.Context, teamRef string) (*Startup, error) {
 	req := graphql.NewRequest(`
-		mutation($issueId: String!, $body: String!) {
-			commentCreate(input: {
-				issueId: $issueId
-				body: $body
-			}) {
-				success
-				comment {
+		query($team: String!, $teamID: ID!, $states: [String!]) {
+			viewer {
+				id
+				name
+				displayName
+				admin
+				guest
+				teams {
+					nodes {
+						id
+						name
+						key
+					}
+				}
+			}
+			teams {
+				nodes {
 					id
+					name
+					key
+				}
+			}
+			issues(filter: {
+				team: {
+					or: [
+						{ key: { eqIgnoreCase: $team } }
+						{ name: { eqIgnoreCase: $team } }
+						{ id: { eq: $teamID } }
+					]
+				}
+				state: {
+					name: {
+						in: $states
+					}
+				}
+			}) {
+				nodes {
+					` + listFields + `
 				}
 			}
 		}
 	`)
 
-	req.Var("issueId", issueID)
-	req.Var("body", body)
-
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", c.apiKey)
-	}
+	// The same reference is tried as a key, name, and ID; IDs compare as ID
+	req.Var("team", teamRef)
+	req.Var("teamID", teamRef)
+	req.Var("states", c.stateNames())
 
 	var resp struct {
-		CommentCreate struct {
-			Success bool `json:"success"`
+		Viewer Viewer `json:"viewer"`
+		Teams  struct {
+			Nodes []Team `json:"nodes"`
+		} `json:"teams"`
+		Issues struct {
+			Nodes []Issue `json:"nodes"`
+		} `json:"issues"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	c.SortByState(resp.Issues.Nodes)
+	return &Startup{Viewer: resp.Viewer, Teams: resp.Teams.Nodes, Issues: resp.Issues.Nodes}, nil
+}
+
+// AddComment adds a comment to an issue
+func (c *Client) AddComment(ctx context.Context, issueID string, body string) error {
+	req := graphql.NewRequest(`
+		mutation($issueId: String!, $body: String!) {
+			commentCreate(input: {
+				issueId: $issueId
+				body: $body
+			}) {
+				success
+				comment {
+					id
+				}
+			}
+		}
+	`)
+
+	req.Var("issueId", issueID)
+	req.Var("body", body)
+
+	var resp struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
 		} `json:"commentCreate"`
 	}
 
-	if err := c.client.Run(ctx, req, &resp); err != nil {
+	if err := c.run(ctx, req, &resp); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// AddReply posts a comment as a reply in the thread of parentID
+func (c *Client) AddReply(ctx context.Context, issueID string, parentID string, body string) error {
+	req := graphql.NewRequest(`
+		mutation($issueId: String!, $parentId: String!, $body: String!) {
+			commentCreate(input: {
+				issueId: $issueId
+				parentId: $parentId
+				body: $body
+			}) {
+				success
+			}
+		}
+	`)
+
+	req.Var("issueId", issueID)
+	req.Var("parentId", parentID)
+	req.Var("body", body)
+
+	var resp struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// UpdateComment replaces the body of an existing comment
+func (c *Client) UpdateComment(ctx context.Context, commentID string, body string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!, $body: String!) {
+			commentUpdate(id: $id, input: {
+				body: $body
+			}) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", commentID)
+	req.Var("body", body)
+
+	var resp struct {
+		CommentUpdate struct {
+			Success bool `json:"success"`
+		} `json:"commentUpdate"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// AddReaction adds an emoji reaction to a comment
+func (c *Client) AddReaction(ctx context.Context, commentID string, emoji string) error {
+	req := graphql.NewRequest(`
+		mutation($commentId: String!, $emoji: String!) {
+			reactionCreate(input: {
+				commentId: $commentId
+				emoji: $emoji
+			}) {
+				success
+			}
+		}
+	`)
+
+	req.Var("commentId", commentID)
+	req.Var("emoji", emoji)
+
+	var resp struct {
+		ReactionCreate struct {
+			Success bool `json:"success"`
+		} `json:"reactionCreate"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// RemoveReaction deletes a reaction
+func (c *Client) RemoveReaction(ctx context.Context, reactionID string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!) {
+			reactionDelete(id: $id) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", reactionID)
+
+	var resp struct {
+		ReactionDelete struct {
+			Success bool `json:"success"`
+		} `json:"reactionDelete"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// ActivityIssue is an issue reference returned by activity queries
+type ActivityIssue struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+}
+
+// StateChange records an issue moving between workflow states
+type StateChange struct {
+	Issue     ActivityIssue `json:"issue"`
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	CreatedAt string        `json:"createdAt"`
+}
+
+// Activity summarizes the viewer's activity over a time range
+type Activity struct {
+	Created   []ActivityIssue `json:"created"`
+	Completed []ActivityIssue `json:"completed"`
+	Commented []ActivityIssue `json:"commented"`
+	Moved     []StateChange   `json:"moved"`
+	// Capped is set when more issues were updated in the range than
+	// activityScanLimit, so Moved may miss some state changes
+	Capped bool `json:"capped,omitempty"`
+}
+
+// activityScanLimit caps how many recently updated issues GetActivity
+// searches for state changes the viewer made
+const activityScanLimit = 2000
+
+// activityPageSize is the page size of the activity queries that nest each
+// issue's history, kept below issuePageSize to stay within query complexity
+const activityPageSize = 50
+
+// historyEntry is the part of an issue history entry activity needs
+type historyEntry struct {
+	CreatedAt string `json:"createdAt"`
+	Actor     *struct {
+		ID string `json:"id"`
+	} `json:"actor"`
+	FromState *struct {
+		Name string `json:"name"`
+	} `json:"fromState"`
+	ToState *struct {
+		Name string `json:"name"`
+	} `json:"toState"`
+}
+
+// historyFields is the selection of historyEntry
+const historyFields = `
+						createdAt
+						actor {
+							id
+						}
+						fromState {
+							name
+						}
+						toState {
+							name
+						}
+`
+
+// GetActivity fetches issues the viewer created, completed, commented on,
+// and moved between states since the given time. Every list is paged
+// through; only the search for state changes is capped, which Capped
+// reports.
+func (c *Client) GetActivity(ctx context.Context, since time.Time) (*Activity, error) {
+	viewer, err := c.GetViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := since.UTC().Format(time.RFC3339)
+	isMe := map[string]interface{}{"isMe": map[string]interface{}{"eq": true}}
+
+	created, err := c.issuePages(ctx, map[string]interface{}{
+		"creator":   isMe,
+		"createdAt": map[string]interface{}{"gte": start},
+	})
+	if err != nil {
+		return nil, err
+	}
+	completed, err := c.issuePages(ctx, map[string]interface{}{
+		"assignee":    isMe,
+		"completedAt": map[string]interface{}{"gte": start},
+	})
+	if err != nil {
+		return nil, err
+	}
+	commented, err := c.commentedIssues(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	moved, capped, err := c.stateChangesBy(ctx, viewer.ID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := &Activity{Commented: commented, Moved: moved, Capped: capped}
+	for _, issue := range created {
+		activity.Created = append(activity.Created, issue.activityIssue())
+	}
+	for _, issue := range completed {
+		activity.Completed = append(activity.Completed, issue.activityIssue())
+	}
+	return activity, nil
+}
+
+// activityIssue is the reference to i that activity reports show
+func (i Issue) activityIssue() ActivityIssue {
+	return ActivityIssue{Identifier: i.Identifier, Title: i.Title, URL: i.URL}
+}
+
+// commentedIssues pages through the viewer's comments created since start,
+// an RFC 3339 time, returning each commented issue once
+func (c *Client) commentedIssues(ctx context.Context, start string) ([]ActivityIssue, error) {
+	var issues []ActivityIssue
+	seen := make(map[string]bool)
+	var cursor *string
+	for {
+		req := graphql.NewRequest(`
+			query($since: DateTimeOrDuration!, $first: Int, $after: String) {
+				comments(filter: {
+					user: { isMe: { eq: true } }
+					createdAt: { gte: $since }
+				}, first: $first, after: $after) {
+					nodes {
+						issue {
+							identifier
+							title
+							url
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		`)
+
+		req.Var("since", start)
+		req.Var("first", issuePageSize)
+		req.Var("after", cursor)
+
+		var resp struct {
+			Comments struct {
+				Nodes []struct {
+					Issue *ActivityIssue `json:"issue"`
+				} `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"comments"`
+		}
+
+		if err := c.run(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, comment := range resp.Comments.Nodes {
+			// Comments on project updates and documents have no issue
+			if comment.Issue != nil && !seen[comment.Issue.Identifier] {
+				seen[comment.Issue.Identifier] = true
+				issues = append(issues, *comment.Issue)
+			}
+		}
+		page := resp.Comments.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return issues, nil
+		}
+		cursor = &page.EndCursor
+	}
+}
+
+// stateChangesBy searches the issues updated since the given time, whoever
+// they are assigned to, for state changes actorID made, oldest first. It
+// stops after activityScanLimit issues and reports whether it did.
+func (c *Client) stateChangesBy(ctx context.Context, actorID string, since time.Time) ([]StateChange, bool, error) {
+	var changes []StateChange
+	scanned := 0
+	var cursor *string
+	for {
+		req := graphql.NewRequest(`
+			query($since: DateTimeOrDuration!, $first: Int, $after: String) {
+				issues(filter: {
+					updatedAt: { gte: $since }
+				}, first: $first, after: $after) {
+					nodes {
+						id
+						identifier
+						title
+						url
+						history(first: 50) {
+							nodes {
+								` + historyFields + `
+							}
+							pageInfo {
+								hasNextPage
+								endCursor
+							}
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		`)
+
+		req.Var("since", since.UTC().Format(time.RFC3339))
+		req.Var("first", activityPageSize)
+		req.Var("after", cursor)
+
+		var resp struct {
+			Issues struct {
+				Nodes []struct {
+					ActivityIssue
+					ID      string `json:"id"`
+					History struct {
+						Nodes    []historyEntry `json:"nodes"`
+						PageInfo pageInfo       `json:"pageInfo"`
+					} `json:"history"`
+				} `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"issues"`
+		}
+
+		if err := c.run(ctx, req, &resp); err != nil {
+			return nil, false, err
+		}
+
+		for _, issue := range resp.Issues.Nodes {
+			entries := issue.History.Nodes
+			if page := issue.History.PageInfo; page.HasNextPage && page.EndCursor != "" {
+				rest, err := c.historyAfter(ctx, issue.ID, page.EndCursor)
+				if err != nil {
+					return nil, false, err
+				}
+				entries = append(entries, rest...)
+			}
+			for _, entry := range entries {
+				if entry.ToState == nil || entry.Actor == nil || entry.Actor.ID != actorID {
+					continue
+				}
+				if at, err := time.Parse(time.RFC3339, entry.CreatedAt); err != nil || at.Before(since) {
+					continue
+				}
+				change := StateChange{
+					Issue:     issue.ActivityIssue,
+					To:        entry.ToState.Name,
+					CreatedAt: entry.CreatedAt,
+				}
+				if entry.FromState != nil {
+					change.From = entry.FromState.Name
+				}
+				changes = append(changes, change)
+			}
+		}
+
+		scanned += len(resp.Issues.Nodes)
+		page := resp.Issues.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" || scanned >= activityScanLimit {
+			sort.SliceStable(changes, func(i, j int) bool { return changes[i].CreatedAt < changes[j].CreatedAt })
+			return changes, page.HasNextPage && scanned >= activityScanLimit, nil
+		}
+		cursor = &page.EndCursor
+	}
+}
+
+// historyAfter fetches the rest of an issue's history, starting after cursor
+func (c *Client) historyAfter(ctx context.Context, issueID, cursor string) ([]historyEntry, error) {
+	var entries []historyEntry
+	for {
+		req := graphql.NewRequest(`
+			query($id: String!, $first: Int, $after: String) {
+				issue(id: $id) {
+					history(first: $first, after: $after) {
+						nodes {
+							` + historyFields + `
+						}
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			}
+		`)
+
+		req.Var("id", issueID)
+		req.Var("first", issuePageSize)
+		req.Var("after", cursor)
+
+		var resp struct {
+			Issue struct {
+				History struct {
+					Nodes    []historyEntry `json:"nodes"`
+					PageInfo pageInfo       `json:"pageInfo"`
+				} `json:"history"`
+			} `json:"issue"`
+		}
+
+		if err := c.run(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, resp.Issue.History.Nodes...)
+		page := resp.Issue.History.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return entries, nil
+		}
+		cursor = page.EndCursor
+	}
+}
+
+// CreateIssue creates a new issue in the given team and returns it
+func (c *Client) CreateIssue(ctx context.Context, teamID string, title string, description string) (*Issue, error) {
+	req := graphql.NewRequest(`
+		mutation($teamId: String!, $title: String!, $description: String) {
+			issueCreate(input: {
+				teamId: $teamId
+				title: $title
+				description: $description
+			}) {
+				success
+				issue {
+					` + issueFields + `
+				}
+			}
+		}
+	`)
+
+	req.Var("teamId", teamID)
+	req.Var("title", title)
+	req.Var("description", description)
+
+	var resp struct {
+		IssueCreate struct {
+			Success bool  `json:"success"`
+			Issue   Issue `json:"issue"`
+		} `json:"issueCreate"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.IssueCreate.Success {
+		return nil, errors.New("issue was not created")
+	}
+
+	resp.IssueCreate.Issue.Detailed = true
+	return &resp.IssueCreate.Issue, nil
+}
+
+// Notification represents an inbox notification about an issue
+type Notification struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"createdAt"`
+	ReadAt    string `json:"readAt"`
+	Actor     struct {
+		Name string `json:"name"`
+	} `json:"actor"`
+	Issue struct {
+		ID         string `json:"id"`
+		Identifier string `json:"identifier"`
+		Title      string `json:"title"`
+		URL        string `json:"url"`
+	} `json:"issue"`
+}
+
+// GetNotifications fetches the viewer's most recent issue notifications
+func (c *Client) GetNotifications(ctx context.Context) ([]Notification, error) {
+	req := graphql.NewRequest(`
+		query {
+			notifications(first: 100) {
+				nodes {
+					id
+					type
+					createdAt
+					readAt
+					actor {
+						name
+					}
+					... on IssueNotification {
+						issue {
+							id
+							identifier
+							title
+							url
+						}
+					}
+				}
+			}
+		}
+	`)
+
+	var resp struct {
+		Notifications struct {
+			Nodes []Notification `json:"nodes"`
+		} `json:"notifications"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Notifications.Nodes, nil
+}
+
+// GetTeamMembers fetches the members of a team
+func (c *Client) GetTeamMembers(ctx context.Context, teamID string) ([]User, error) {
+	req := graphql.NewRequest(`
+		query($teamId: String!) {
+			team(id: $teamId) {
+				members {
+					nodes {
+						id
+						name
+						displayName
+						active
+						url
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("teamId", teamID)
+
+	var resp struct {
+		Team struct {
+			Members struct {
+				Nodes []User `json:"nodes"`
+			} `json:"members"`
+		} `json:"team"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Team.Members.Nodes, nil
+}
+
+// GetStateEnteredAt returns when an issue last moved into its current state,
+// falling back to its creation time if the history has no such transition
+func (c *Client) GetStateEnteredAt(ctx context.Context, issueID string) (time.Time, error) {
+	req := graphql.NewRequest(`
+		query($id: String!) {
+			issue(id: $id) {
+				createdAt
+				state {
+					name
+				}
+				history(first: 50) {
+					nodes {
+						createdAt
+						toState {
+							name
+						}
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		Issue struct {
+			CreatedAt string `json:"createdAt"`
+			State     struct {
+				Name string `json:"name"`
+			} `json:"state"`
+			History struct {
+				Nodes []struct {
+					CreatedAt string `json:"createdAt"`
+					ToState   *struct {
+						Name string `json:"name"`
+					} `json:"toState"`
+				} `json:"nodes"`
+			} `json:"history"`
+		} `json:"issue"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return time.Time{}, err
+	}
+
+	var entered time.Time
+	for _, entry := range resp.Issue.History.Nodes {
+		if entry.ToState == nil || entry.ToState.Name != resp.Issue.State.Name {
+			continue
+		}
+		if at, err := time.Parse(time.RFC3339, entry.CreatedAt); err == nil && at.After(entered) {
+			entered = at
+		}
+	}
+	if entered.IsZero() {
+		return time.Parse(time.RFC3339, resp.Issue.CreatedAt)
+	}
+	return entered, nil
+}
+
+// HistoryEntry is one change in an issue's history. Only the pointer and
+// slice fields describing what changed are set.
+type HistoryEntry struct {
+	CreatedAt string `json:"createdAt"`
+	Actor     *struct {
+		Name string `json:"name"`
+	} `json:"actor"`
+	FromState *struct {
+		Name string `json:"name"`
+	} `json:"fromState"`
+	ToState *struct {
+		Name string `json:"name"`
+	} `json:"toState"`
+	FromAssignee *struct {
+		Name string `json:"name"`
+	} `json:"fromAssignee"`
+	ToAssignee *struct {
+		Name string `json:"name"`
+	} `json:"toAssignee"`
+	AddedLabels []struct {
+		Name string `json:"name"`
+	} `json:"addedLabels"`
+	RemovedLabels []struct {
+		Name string `json:"name"`
+	} `json:"removedLabels"`
+}
+
+// GetIssueHistory fetches the state, assignee, and label changes of an issue
+func (c *Client) GetIssueHistory(ctx context.Context, issueID string) ([]HistoryEntry, error) {
+	req := graphql.NewRequest(`
+		query($id: String!) {
+			issue(id: $id) {
+				history(first: 50) {
+					nodes {
+						createdAt
+						actor {
+							name
+						}
+						fromState {
+							name
+						}
+						toState {
+							name
+						}
+						fromAssignee {
+							name
+						}
+						toAssignee {
+							name
+						}
+						addedLabels {
+							name
+						}
+						removedLabels {
+							name
+						}
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		Issue struct {
+			History struct {
+				Nodes []HistoryEntry `json:"nodes"`
+			} `json:"history"`
+		} `json:"issue"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Issue.History.Nodes, nil
+}
+
+// AssignIssue sets the assignee of an issue; an empty assigneeID unassigns it
+func (c *Client) AssignIssue(ctx context.Context, issueID string, assigneeID string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!, $assigneeId: String) {
+			issueUpdate(id: $id, input: {
+				assigneeId: $assigneeId
+			}) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+	if assigneeID != "" {
+		req.Var("assigneeId", assigneeID)
+	} else {
+		req.Var("assigneeId", nil)
+	}
+
+	var resp struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// SubscribeToIssue adds a user to an issue's subscribers
+func (c *Client) SubscribeToIssue(ctx context.Context, issueID string, userID string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!, $userId: String) {
+			issueSubscribe(id: $id, userId: $userId) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+	req.Var("userId", userID)
+
+	var resp struct {
+		IssueSubscribe struct {
+			Success bool `json:"success"`
+		} `json:"issueSubscribe"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// GetIssue fetches a single issue by ID or identifier
+func (c *Client) GetIssue(ctx context.Context, issueID string) (*Issue, error) {
+	req := graphql.NewRequest(`
+		query($id: String!) {
+			issue(id: $id) {
+				` + issueFields + `
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		Issue Issue `json:"issue"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Issue.Detailed = true
+	return &resp.Issue, nil
+}
+
+// GetIssuesByIdentifier fetches the issues with the given identifiers, such
+// as ENG-123, in one query; identifiers matching no issue are left out
+func (c *Client) GetIssuesByIdentifier(ctx context.Context, identifiers []string) ([]Issue, error) {
+	// Filters match a team key and issue number rather than an identifier
+	var keys []string
+	numbers := make(map[string][]int)
+	for _, identifier := range identifiers {
+		key, number, ok := strings.Cut(strings.ToUpper(identifier), "-")
+		n, err := strconv.Atoi(number)
+		if !ok || err != nil {
+			continue
+		}
+		if _, seen := numbers[key]; !seen {
+			keys = append(keys, key)
+		}
+		numbers[key] = append(numbers[key], n)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var teams []interface{}
+	for _, key := range keys {
+		teams = append(teams, map[string]interface{}{
+			"team":   map[string]interface{}{"key": map[string]interface{}{"eq": key}},
+			"number": map[string]interface{}{"in": numbers[key]},
+		})
+	}
+	return c.issuePages(ctx, map[string]interface{}{"or": teams})
+}
+
+// GetCompletedIssues fetches every issue completed since the given time,
+// oldest first, in one team or, with an empty teamID, in all teams
+func (c *Client) GetCompletedIssues(ctx context.Context, teamID string, since time.Time) ([]Issue, error) {
+	filter := map[string]interface{}{
+		"completedAt": map[string]interface{}{"gte": since.UTC().Format(time.RFC3339)},
+	}
+	if teamID != "" {
+		filter["team"] = map[string]interface{}{"id": map[string]interface{}{"eq": teamID}}
+	}
+	issues, err := c.issuePages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Timestamps share one RFC 3339 layout, so they sort as strings
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].CompletedAt < issues[j].CompletedAt
+	})
+	return issues, nil
+}
+
+// GetSubIssues fetches the unarchived sub-issues of an issue
+func (c *Client) GetSubIssues(ctx context.Context, issueID string) ([]RelatedIssue, error) {
+	req := graphql.NewRequest(`
+		query($id: String!) {
+			issue(id: $id) {
+				children {
+					nodes {
+						id
+						identifier
+						title
+						state {
+							name
+							type
+						}
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		Issue struct {
+			Children struct {
+				Nodes []RelatedIssue `json:"nodes"`
+			} `json:"children"`
+		} `json:"issue"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Issue.Children.Nodes, nil
+}
+
+// ArchiveIssue archives an issue
+func (c *Client) ArchiveIssue(ctx context.Context, issueID string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!) {
+			issueArchive(id: $id) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		IssueArchive struct {
+			Success bool `json:"success"`
+		} `json:"issueArchive"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// UnarchiveIssue restores an archived issue
+func (c *Client) UnarchiveIssue(ctx context.Context, issueID string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!) {
+			issueUnarchive(id: $id) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		IssueUnarchive struct {
+			Success bool `json:"success"`
+		} `json:"issueUnarchive"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// WorkflowState is a state in a team's workflow
+type WorkflowState struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetTeamStates fetches the workflow states of a team
+func (c *Client) GetTeamStates(ctx context.Context, teamID string) ([]WorkflowState, error) {
+	req := graphql.NewRequest(`
+		query($teamId: String!) {
+			team(id: $teamId) {
+				states {
+					nodes {
+						id
+						name
+						type
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("teamId", teamID)
+
+	var resp struct {
+		Team struct {
+			States struct {
+				Nodes []WorkflowState `json:"nodes"`
+			} `json:"states"`
+		} `json:"team"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Team.States.Nodes, nil
+}
+
+// GetStateNames fetches the distinct workflow state names of one team or,
+// with an empty teamID, of every team
+func (c *Client) GetStateNames(ctx context.Context, teamID string) ([]string, error) {
+	filter := map[string]interface{}{}
+	if teamID != "" {
+		filter["team"] = map[string]interface{}{"id": map[string]interface{}{"eq": teamID}}
+	}
+	seen := make(map[string]bool)
+	var names []string
+	var after *string
+	for {
+		req := graphql.NewRequest(`
+			query($filter: WorkflowStateFilter, $first: Int, $after: String) {
+				workflowStates(filter: $filter, first: $first, after: $after) {
+					nodes {
+						name
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		`)
+
+		req.Var("filter", filter)
+		req.Var("first", issuePageSize)
+		req.Var("after", after)
+
+		var resp struct {
+			WorkflowStates struct {
+				Nodes []struct {
+					Name string `json:"name"`
+				} `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"workflowStates"`
+		}
+
+		if err := c.run(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, state := range resp.WorkflowStates.Nodes {
+			if !seen[state.Name] {
+				seen[state.Name] = true
+				names = append(names, state.Name)
+			}
+		}
+		page := resp.WorkflowStates.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return names, nil
+		}
+		after = &page.EndCursor
+	}
+}
+
+// SetIssueState moves an issue to a workflow state
+func (c *Client) SetIssueState(ctx context.Context, issueID string, stateID string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!, $stateId: String!) {
+			issueUpdate(id: $id, input: {
+				stateId: $stateId
+			}) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+	req.Var("stateId", stateID)
+
+	var resp struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
+
+// CustomerNeed is a customer request attached to an issue
+type CustomerNeed struct {
+	Body      string  `json:"body"`
+	Priority  float64 `json:"priority"`
+	CreatedAt string  `json:"createdAt"`
+	Customer  *struct {
+		Name string `json:"name"`
+	} `json:"customer"`
+}
+
+// GetCustomerNeeds fetches the customer requests attached to an issue
+func (c *Client) GetCustomerNeeds(ctx context.Context, issueID string) ([]CustomerNeed, error) {
+	req := graphql.NewRequest(`
+		query($id: String!) {
+			issue(id: $id) {
+				needs {
+					nodes {
+						body
+						priority
+						createdAt
+						customer {
+							name
+						}
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		Issue struct {
+			Needs struct {
+				Nodes []CustomerNeed `json:"nodes"`
+			} `json:"needs"`
+		} `json:"issue"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Issue.Needs.Nodes, nil
+}
+
+// RecurringTemplate is the recurring issue template an issue was created from
+type RecurringTemplate struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetRecurringTemplate returns the recurring issue template that created an
+// issue, or nil when the issue is not part of a recurring schedule
+func (c *Client) GetRecurringTemplate(ctx context.Context, issueID string) (*RecurringTemplate, error) {
+	req := graphql.NewRequest(`
+		query($id: String!) {
+			issue(id: $id) {
+				recurringIssueTemplate {
+					id
+					name
+				}
+			}
+		}
+	`)
+
+	req.Var("id", issueID)
+
+	var resp struct {
+		Issue struct {
+			RecurringIssueTemplate *RecurringTemplate `json:"recurringIssueTemplate"`
+		} `json:"issue"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Issue.RecurringIssueTemplate, nil
+}
+
+// Label is an issue label available to a team
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// GetTeamLabels returns the labels usable on a team's issues, including
+// workspace-wide labels, sorted by name
+func (c *Client) GetTeamLabels(ctx context.Context, teamID string) ([]Label, error) {
+	req := graphql.NewRequest(`
+		query($teamId: String!) {
+			team(id: $teamId) {
+				labels(first: 250, includeArchived: false) {
+					nodes {
+						id
+						name
+						color
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("teamId", teamID)
+
+	var resp struct {
+		Team struct {
+			Labels struct {
+				Nodes []Label `json:"nodes"`
+			} `json:"labels"`
+		} `json:"team"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	labels := resp.Team.Labels.Nodes
+	sort.Slice(labels, func(i, j int) bool {
+		return strings.ToLower(labels[i].Name) < strings.ToLower(labels[j].Name)
+	})
+	return labels, nil
+}
+
+// CreateLabel adds a label to a team
+func (c *Client) CreateLabel(ctx context.Context, teamID, name string) (*Label, error) {
+	req := graphql.NewRequest(`
+		mutation($teamId: String!, $name: String!) {
+			issueLabelCreate(input: { teamId: $teamId, name: $name }) {
+				success
+				issueLabel {
+					id
+					name
+					color
+				}
+			}
+		}
+	`)
+
+	req.Var("teamId", teamID)
+	req.Var("name", name)
+
+	var resp struct {
+		IssueLabelCreate struct {
+			Success    bool  `json:"success"`
+			IssueLabel Label `json:"issueLabel"`
+		} `json:"issueLabelCreate"`
+	}
+
+	if err := c.run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.IssueLabelCreate.IssueLabel, nil
+}
+
+// RenameLabel changes a label's name
+func (c *Client) RenameLabel(ctx context.Context, labelID, name string) error {
+	req := graphql.NewRequest(`
+		mutation($id: String!, $name: String!) {
+			issueLabelUpdate(id: $id, input: { name: $name }) {
+				success
+			}
+		}
+	`)
+
+	req.Var("id", labelID)
+	req.Var("name", name)
+
+	var resp struct {
+		IssueLabelUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueLabelUpdate"`
+	}
+
+	return c.run(ctx, req, &resp)
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+)
+
+// runActivity prints a markdown summary of the viewer's recent activity
+func runActivity(args []string, client *api.Client, cfg *config.Config) int {
+	fs := flag.NewFlagSet("activity", flag.ContinueOnError)
+	sinceFlag := fs.String("since", "monday", "start of the range: weekday, today, yesterday, Nd, or YYYY-MM-DD")
+	output := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if err := output.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	since, err := parseSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	activity, err := client.GetActivity(context.Background(), since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching activity: %v\n", err)
+		return exitError
+	}
+
+	if activity.Capped && (*output.json || *output.tsv) {
+		fmt.Fprintln(os.Stderr, "Warning: "+cappedNote)
+	}
+	switch {
+	case *output.json:
+		err = writeJSON(os.Stdout, activity)
+	case *output.tsv:
+		err = writeActivityTSV(os.Stdout, activity)
+	default:
+		writeActivity(os.Stdout, activity, since)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// cappedNote explains an activity report whose search for state changes
+// stopped early
+const cappedNote = "too many issues were updated in this range to search them all, so Moved may be incomplete; use a later --since for a complete list"
+
+// writeActivityTSV writes one row per activity entry; kind is the JSON
+// section name, and from/to are only set for moved issues
+func writeActivityTSV(w io.Writer, activity *api.Activity) error {
+	header := []string{"kind", "identifier", "title", "url", "from", "to", "createdAt"}
+	var records [][]string
+	sections := []struct {
+		kind   string
+		issues []api.ActivityIssue
+	}{
+		{"created", activity.Created},
+		{"completed", activity.Completed},
+		{"commented", activity.Commented},
+	}
+	for _, section := range sections {
+		for _, issue := range section.issues {
+			records = append(records, []string{section.kind, issue.Identifier, issue.Title, issue.URL, "", "", ""})
+		}
+	}
+	for _, change := range activity.Moved {
+		records = append(records, []string{"moved", change.Issue.Identifier, change.Issue.Title, change.Issue.URL, change.From, change.To, change.CreatedAt})
+	}
+	return writeTSV(w, header, records)
+}
+
+// writeActivity renders activity as grouped markdown
+func writeActivity(w io.Writer, activity *api.Activity, since time.Time) {
+	fmt.Fprintf(w, "# Activity since %s\n", since.Format("Monday, Jan 2 2006"))
+	if activity.Capped {
+		fmt.Fprintf(w, "\n> Note: %s.\n", cappedNote)
+	}
+
+	sections := []struct {
+		title  string
+		issues []api.ActivityIssue
+	}{
+		{"Created", activity.Created},
+		{"Completed", activity.Completed},
+		{"Commented on", activity.Commented},
+	}
+	for _, section := range sections {
+		if len(section.issues) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n## %s\n\n", section.title)
+		for _, issue := range section.issues {
+			fmt.Fprintf(w, "- [%s](%s) %s %s\n", issue.Identifier, issue.URL, issue.Title, deepLink(issue.Identifier))
+		}
+	}
+
+	if len(activity.Moved) > 0 {
+		fmt.Fprintf(w, "\n## Moved\n\n")
+		for _, change := range activity.Moved {
+			from := change.From
+			if from == "" {
+				from = "?"
+			}
+			fmt.Fprintf(w, "- [%s](%s) %s: %s → %s %s\n", change.Issue.Identifier, change.Issue.URL, change.Issue.Title, from, change.To, deepLink(change.Issue.Identifier))
+		}
+	}
+}
+
+// deepLink returns the command that opens an issue in the TUI, formatted as
+// inline code for markdown reports
+func deepLink(identifier string) string {
+	return "`lazylinear " + identifier + "`"
+}
+
+// parseSince resolves a human-friendly start date relative to now. It accepts
+// weekday names (the most recent such day, including today), "today",
+// "yesterday", day counts like "7d", and ISO dates.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if value == strings.ToLower(day.String()) {
+			diff := (int(today.Weekday()) - int(day) + 7) % 7
+			return today.AddDate(0, 0, -diff), nil
+		}
+	}
+
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return today.AddDate(0, 0, -days), nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since value %q", value)
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+)
+
+// changelogSections are the well-known labels given their own section, in
+// the order sections are written; an issue goes into the first that applies
+var changelogSections = []struct {
+	title  string
+	labels []string
+}{
+	{"Features", []string{"feature", "new feature"}},
+	{"Improvements", []string{"improvement", "enhancement"}},
+	{"Bug fixes", []string{"bug", "bug fix", "fix"}},
+}
+
+// runChangelog prints the issues completed since a git tag or date as a
+// markdown changelog, e.g. `lazylinear changelog --since v1.2.0`
+func runChangelog(args []string, client *api.Client, cfg *config.Config) int {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	sinceFlag := fs.String("since", "", "git tag or ref, or a date: weekday, today, yesterday, Nd, or YYYY-MM-DD (required)")
+	team := fs.String("team", "", "team key, name, or ID (default: all teams)")
+	groupBy := fs.String("group-by", "label", `group issues by "label" or "project"`)
+	output := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if err := output.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if *sinceFlag == "" {
+		fmt.Fprintln(os.Stderr, "--since is required")
+		return exitError
+	}
+	if *groupBy != "label" && *groupBy != "project" {
+		fmt.Fprintf(os.Stderr, "invalid --group-by %q: want label or project\n", *groupBy)
+		return exitError
+	}
+
+	since, err := resolveSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	ctx := context.Background()
+	teamID, err := resolveTeam(ctx, client, *team)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	issues, err := client.GetCompletedIssues(ctx, teamID, since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading completed issues: %v\n", err)
+		return exitError
+	}
+
+	if *output.json || *output.tsv {
+		err = writeIssues(os.Stdout, issues, output)
+	} else {
+		writeChangelog(os.Stdout, issues, *sinceFlag, since, *groupBy)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// resolveSince accepts the dates parseSince does, or else a git ref whose
+// commit time starts the range
+func resolveSince(value string, now time.Time) (time.Time, error) {
+	if t, err := parseSince(value, now); err == nil {
+		return t, nil
+	}
+	out, err := exec.Command("git", "log", "-1", "--format=%cI", value, "--").Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value %q: not a date or git ref", value)
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+}
+
+// writeChangelog renders completed issues as markdown, grouped by label
+// section or by project
+func writeChangelog(w io.Writer, issues []api.Issue, sinceLabel string, since time.Time, groupBy string) {
+	fmt.Fprintf(w, "# Changes since %s\n\n", sinceLabel)
+	noun := "issues"
+	if len(issues) == 1 {
+		noun = "issue"
+	}
+	fmt.Fprintf(w, "_%d %s completed since %s._\n", len(issues), noun, since.Format("Jan 2 2006"))
+
+	var order []string
+	groups := make(map[string][]api.Issue)
+	for _, issue := range issues {
+		group := changelogSection(issue)
+		if groupBy == "project" {
+			group = issue.Project.Name
+		}
+		if _, ok := groups[group]; !ok {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], issue)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return groupRank(order[i], groupBy) < groupRank(order[j], groupBy)
+	})
+
+	for _, group := range order {
+		title := group
+		if title == "" {
+			title = "Other"
+		}
+		fmt.Fprintf(w, "\n## %s\n\n", title)
+		for _, issue := range groups[group] {
+			fmt.Fprintf(w, "- %s ([%s](%s))%s\n", issue.Title, issue.Identifier, issue.URL, changelogDetail(issue, groupBy))
+		}
+	}
+}
+
+// changelogSection returns the section of an issue's labels: a well-known
+// section first, then its first label, or "" for unlabeled issues
+func changelogSection(issue api.Issue) string {
+	for _, section := range changelogSections {
+		for _, label := range issue.Labels.Nodes {
+			for _, name := range section.labels {
+				if strings.EqualFold(label.Name, name) {
+					return section.title
+				}
+			}
+		}
+	}
+	if len(issue.Labels.Nodes) > 0 {
+		return issue.Labels.Nodes[0].Name
+	}
+	return ""
+}
+
+// groupRank orders groups: well-known sections first, then the rest in the
+// order first seen, with the ungrouped "" last
+func groupRank(group, groupBy string) int {
+	if group == "" {
+		return len(changelogSections) + 1
+	}
+	if groupBy == "label" {
+		for i, section := range changelogSections {
+			if section.title == group {
+				return i
+			}
+		}
+	}
+	return len(changelogSections)
+}
+
+// changelogDetail notes what the grouping leaves out: the project when
+// grouping by label, the labels when grouping by project
+func changelogDetail(issue api.Issue, groupBy string) string {
+	if groupBy == "label" {
+		if issue.Project.Name == "" {
+			return ""
+		}
+		return " — " + issue.Project.Name
+	}
+	var labels []string
+	for _, label := range issue.Labels.Nodes {
+		labels = append(labels, label.Name)
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	return " — " + strings.Join(labels, ", ")
+}
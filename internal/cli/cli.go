@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+)
+
+// Exit codes shared by all subcommands
+const (
+	exitOK    = 0
+	exitFound = 1 // --quiet: matching issues exist
+	exitError = 2 // usage or API error
+)
+
+// command is a CLI subcommand entry point returning a process exit code
+type command func(args []string, client *api.Client, cfg *config.Config) int
+
+// commands maps subcommand names to their implementations
+var commands = map[string]command{
+	"activity":  runActivity,
+	"changelog": runChangelog,
+	"create":    runCreate,
+	"get":       runGet,
+	"list":      runList,
+	"pick":      runPick,
+	"serve":     runServe,
+}
+
+// IsCommand reports whether name is a known subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Run executes the subcommand named by args[0] and returns its exit code
+func Run(args []string, client *api.Client, cfg *config.Config) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: lazylinear <command> [flags]")
+		return exitError
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		return exitError
+	}
+	return cmd(args[1:], client, cfg)
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+)
+
+// runCreate creates an issue whose description is read from --file or from
+// piped stdin, e.g. `cat bug.md | lazylinear create --team ENG --title "..."`
+func runCreate(args []string, client *api.Client, cfg *config.Config) int {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	team := fs.String("team", cfg.DefaultTeam, "team key, name, or ID (default: default_team from config)")
+	title := fs.String("title", "", "issue title (required)")
+	file := fs.String("file", "", `read the description from this file ("-" for stdin)`)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if strings.TrimSpace(*title) == "" {
+		fmt.Fprintln(os.Stderr, "--title is required")
+		return exitError
+	}
+	if *team == "" {
+		fmt.Fprintln(os.Stderr, "--team is required when no default_team is configured")
+		return exitError
+	}
+
+	description, err := readDescription(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading description: %v\n", err)
+		return exitError
+	}
+
+	ctx := context.Background()
+	teamID, err := resolveTeam(ctx, client, *team)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	issue, err := client.CreateIssue(ctx, teamID, *title, description)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating issue: %v\n", err)
+		return exitError
+	}
+	fmt.Printf("%s\t%s\n", issue.Identifier, issue.URL)
+	return exitOK
+}
+
+// readDescription reads path, or stdin when path is "-" or when path is empty
+// and stdin is not a terminal
+func readDescription(path string) (string, error) {
+	var r io.Reader
+	switch {
+	case path == "-":
+		r = os.Stdin
+	case path != "":
+		data, err := os.ReadFile(path)
+		return string(data), err
+	default:
+		info, err := os.Stdin.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+			return "", nil
+		}
+		r = os.Stdin
+	}
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+	"lazylinear/internal/server"
+)
+
+// serverTimeout bounds asking a running `lazylinear serve` before falling
+// back to the cache file, keeping statusline queries fast
+const serverTimeout = 20 * time.Millisecond
+
+// getCacheEntry is an issue looked up by `get`, and when it was fetched
+type getCacheEntry struct {
+	Issue     api.Issue `json:"issue"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// runGet prints one issue, named by identifier or by the current git
+// branch. It answers from a running `lazylinear serve`, then from
+// ~/.lazylinear/get-cache.json, and only then from the API, so editor
+// statuslines can call it on every redraw, e.g.
+//
+//	lazylinear get --current-branch --json
+func runGet(args []string, client *api.Client, cfg *config.Config) int {
+	opts, err := parseGetArgs(args, os.Stderr)
+	if err != nil {
+		return exitError
+	}
+
+	var key, identifier string
+	if opts.currentBranch {
+		branch, err := gitBranch()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading the current branch: %v\n", err)
+			return exitError
+		}
+		key, identifier = "branch:"+branch, server.BranchIdentifier(branch)
+		var issue *api.Issue
+		if server.Call(defaultSocket(), "issues.branch", map[string]string{"branch": branch}, &issue, serverTimeout) == nil && issue != nil {
+			return printIssue(*issue, opts.json)
+		}
+		if identifier == "" {
+			fmt.Fprintf(os.Stderr, "No issue identifier in branch %q\n", branch)
+			return exitError
+		}
+	} else {
+		identifier = opts.identifier
+		key = identifier
+		// The server matches an identifier like a branch mentioning it
+		var issue *api.Issue
+		if server.Call(defaultSocket(), "issues.branch", map[string]string{"branch": identifier}, &issue, serverTimeout) == nil && issue != nil {
+			return printIssue(*issue, opts.json)
+		}
+	}
+
+	cache := loadGetCache()
+	if entry, ok := cache[key]; ok && time.Since(entry.FetchedAt) < opts.maxAge {
+		return printIssue(entry.Issue, opts.json)
+	}
+
+	issue, err := client.GetIssue(context.Background(), identifier)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", identifier, err)
+		return exitError
+	}
+	// Statuslines need none of the comments and attachments
+	issue.Comments.Nodes, issue.Attachments.Nodes = nil, nil
+	cache[key] = getCacheEntry{Issue: *issue, FetchedAt: time.Now()}
+	saveGetCache(cache, opts.maxAge)
+	return printIssue(*issue, opts.json)
+}
+
+// getOptions are the parsed arguments of `get`
+type getOptions struct {
+	identifier    string
+	currentBranch bool
+	maxAge        time.Duration
+	json          bool
+}
+
+// errGetUsage reports `get` arguments that name no single issue
+var errGetUsage = errors.New("usage: lazylinear get ISSUE-ID [flags]")
+
+// parseGetArgs parses the arguments of `get`. The identifier may come before,
+// between, or after the flags, so both `get ENG-123 --json` and
+// `get --json ENG-123` work. Usage and errors are written to output.
+func parseGetArgs(args []string, output io.Writer) (getOptions, error) {
+	var opts getOptions
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: lazylinear get ISSUE-ID [flags]\n       lazylinear get --current-branch [flags]")
+		fs.PrintDefaults()
+	}
+	fs.BoolVar(&opts.currentBranch, "current-branch", false, "get the issue of the checked out git branch")
+	fs.DurationVar(&opts.maxAge, "max-age", 5*time.Minute, "how old a cached issue may be before it is fetched again")
+	fs.BoolVar(&opts.json, "json", false, "print the issue as JSON using the API field names")
+
+	// Parse stops at the first positional argument, so parse again after it
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return opts, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	switch {
+	case opts.currentBranch && len(positional) == 0:
+	case !opts.currentBranch && len(positional) == 1:
+		opts.identifier = strings.ToUpper(positional[0])
+	default:
+		fs.Usage()
+		return opts, errGetUsage
+	}
+	return opts, nil
+}
+
+// printIssue writes the issue as JSON, or as identifier, state, and title
+// separated by tabs
+func printIssue(issue api.Issue, asJSON bool) int {
+	if asJSON {
+		if err := json.NewEncoder(os.Stdout).Encode(issue); err != nil {
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Printf("%s\t%s\t%s\n", issue.Identifier, issue.State.Name, tsvEscaper.Replace(issue.Title))
+	return exitOK
+}
+
+// gitBranch returns the branch checked out in the working directory
+func gitBranch() (string, error) {
+	out, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("not on a git branch")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// getCachePath is where `get` keeps the issues it fetched
+func getCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lazylinear", "get-cache.json"), nil
+}
+
+// loadGetCache reads the issues fetched by earlier runs; a missing or
+// unreadable file starts afresh
+func loadGetCache() map[string]getCacheEntry {
+	cache := make(map[string]getCacheEntry)
+	path, err := getCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	json.Unmarshal(data, &cache)
+	return cache
+}
+
+// saveGetCache writes the cache back, dropping entries too old to be used
+// again. Failing to save only makes the next run slower, so it is silent.
+func saveGetCache(cache map[string]getCacheEntry, maxAge time.Duration) {
+	for key, entry := range cache {
+		if time.Since(entry.FetchedAt) >= maxAge {
+			delete(cache, key)
+		}
+	}
+	path, err := getCachePath()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	// Write then rename, so concurrent statuslines never read half a file
+	tmp, err := os.CreateTemp(filepath.Dir(path), "get-cache-*.json")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func TestParseGetArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    getOptions
+		wantErr bool
+	}{
+		{"identifier only", []string{"eng-123"}, getOptions{identifier: "ENG-123", maxAge: 5 * time.Minute}, false},
+		{"identifier before flags", []string{"ENG-123", "--json"}, getOptions{identifier: "ENG-123", maxAge: 5 * time.Minute, json: true}, false},
+		{"identifier after flags", []string{"--json", "ENG-123"}, getOptions{identifier: "ENG-123", maxAge: 5 * time.Minute, json: true}, false},
+		{"identifier between flags", []string{"--max-age", "1m", "ENG-123", "--json"}, getOptions{identifier: "ENG-123", maxAge: time.Minute, json: true}, false},
+		{"current branch", []string{"--current-branch", "--json"}, getOptions{currentBranch: true, maxAge: 5 * time.Minute, json: true}, false},
+		{"nothing", nil, getOptions{}, true},
+		{"two identifiers", []string{"ENG-1", "--json", "ENG-2"}, getOptions{}, true},
+		{"branch and identifier", []string{"--current-branch", "ENG-1"}, getOptions{}, true},
+		{"unknown flag", []string{"ENG-1", "--nope"}, getOptions{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGetArgs(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGetArgs(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseGetArgs(%q) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
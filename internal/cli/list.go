@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+	"lazylinear/internal/export"
+)
+
+// runList prints issues matching the given filters. With --quiet nothing is
+// printed and the exit code reports whether any issues matched.
+func runList(args []string, client *api.Client, cfg *config.Config) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	team := fs.String("team", "", "team key, name, or ID (default: all teams)")
+	mine := fs.Bool("mine", false, "only issues assigned to me")
+	state := fs.String("state", "", "only issues in this state, e.g. Blocked")
+	search := fs.String("search", "", "only issues whose title contains this text, ignoring case")
+	quiet := fs.Bool("quiet", false, "print nothing; exit 1 if any issues match, 0 otherwise")
+	output := addOutputFlags(fs)
+	addExportFlags(fs, output)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if err := output.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	ctx := context.Background()
+	teamID, err := resolveTeam(ctx, client, *team)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	query := api.IssueQuery{TeamID: teamID, Search: *search}
+	if *mine {
+		viewer, err := client.GetViewer(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching viewer: %v\n", err)
+			return exitError
+		}
+		query.AssigneeID = viewer.ID
+	}
+	if *state != "" {
+		names, err := client.GetStateNames(ctx, teamID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading workflow states: %v\n", err)
+			return exitError
+		}
+		query.States = matchStates(names, *state, client.CanonicalState)
+		if len(query.States) == 0 {
+			// An empty list would be an all-clear for a state that never matches
+			fmt.Fprintf(os.Stderr, "unknown state %q: want one of %s\n", *state, strings.Join(names, ", "))
+			return exitError
+		}
+	}
+
+	matched, err := client.GetAllIssues(ctx, query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading issues: %v\n", err)
+		return exitError
+	}
+
+	if *quiet {
+		if len(matched) > 0 {
+			return exitFound
+		}
+		return exitOK
+	}
+
+	if err := writeIssues(os.Stdout, matched, output); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// matchStates returns the workflow state names that state names, directly or
+// through a configured alias, ignoring case
+func matchStates(names []string, state string, canonical func(string) string) []string {
+	var matched []string
+	for _, name := range names {
+		if strings.EqualFold(name, state) || strings.EqualFold(canonical(name), state) {
+			matched = append(matched, name)
+		}
+	}
+	return matched
+}
+
+// writeIssues prints issues in the selected output format, defaulting to
+// identifier, state, and title separated by tabs
+func writeIssues(w io.Writer, issues []api.Issue, output outputFlags) error {
+	switch {
+	case *output.csv:
+		return export.CSV(w, issues)
+	case *output.markdown:
+		return export.Markdown(w, issues)
+	case *output.json:
+		if issues == nil {
+			issues = []api.Issue{}
+		}
+		return writeJSON(w, issues)
+	case *output.tsv:
+		header := []string{"identifier", "title", "state", "assignee", "priority", "team", "url", "branchName", "createdAt", "updatedAt"}
+		records := make([][]string, len(issues))
+		for i, issue := range issues {
+			records[i] = []string{
+				issue.Identifier, issue.Title, issue.State.Name, issue.Assignee.Name,
+				strconv.Itoa(int(issue.Priority)), issue.Team.Key, issue.URL,
+				issue.BranchName, issue.CreatedAt, issue.UpdatedAt,
+			}
+		}
+		return writeTSV(w, header, records)
+	}
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", issue.Identifier, issue.State.Name, issue.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveTeam returns the ID of the team matching ref by key, name, or ID;
+// an empty ref resolves to the empty ID, meaning all teams
+func resolveTeam(ctx context.Context, client *api.Client, ref string) (string, error) {
+	if ref == "" {
+		return "", nil
+	}
+	teams, err := client.GetTeams(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading teams: %v", err)
+	}
+	for _, team := range teams {
+		if strings.EqualFold(team.Key, ref) || strings.EqualFold(team.Name, ref) || team.ID == ref {
+			return team.ID, nil
+		}
+	}
+	return "", fmt.Errorf("unknown team %q", ref)
+}
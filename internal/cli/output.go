@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+)
+
+// outputFlags selects a machine-readable output format for a subcommand
+type outputFlags struct {
+	json     *bool
+	tsv      *bool
+	csv      *bool
+	markdown *bool
+}
+
+// addOutputFlags registers --json and --tsv on fs
+func addOutputFlags(fs *flag.FlagSet) outputFlags {
+	return outputFlags{
+		json:     fs.Bool("json", false, "print results as JSON using the API field names"),
+		tsv:      fs.Bool("tsv", false, "print results as tab-separated values with a header row"),
+		csv:      new(bool),
+		markdown: new(bool),
+	}
+}
+
+// addExportFlags additionally registers --csv and --markdown for issue lists
+func addExportFlags(fs *flag.FlagSet, o outputFlags) {
+	fs.BoolVar(o.csv, "csv", false, "print results as CSV with a header row")
+	fs.BoolVar(o.markdown, "markdown", false, "print results as a markdown table")
+}
+
+// validate rejects asking for more than one format at once
+func (o outputFlags) validate() error {
+	n := 0
+	for _, set := range []*bool{o.json, o.tsv, o.csv, o.markdown} {
+		if *set {
+			n++
+		}
+	}
+	if n > 1 {
+		return errors.New("output format flags are mutually exclusive")
+	}
+	return nil
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// tsvEscaper keeps each record on one line with a fixed number of columns
+var tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+// writeTSV writes a header row followed by records, escaping tabs and
+// newlines inside fields
+func writeTSV(w io.Writer, header []string, records [][]string) error {
+	for _, record := range append([][]string{header}, records...) {
+		fields := make([]string, len(record))
+		for i, field := range record {
+			fields[i] = tsvEscaper.Replace(field)
+		}
+		if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
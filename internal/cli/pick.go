@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+)
+
+// runPick prints one `identifier<TAB>title<TAB>branch` line per issue for
+// fuzzy finders, e.g.
+//
+//	git checkout -b "$(lazylinear pick --mine | fzf | cut -f3)"
+func runPick(args []string, client *api.Client, cfg *config.Config) int {
+	fs := flag.NewFlagSet("pick", flag.ContinueOnError)
+	team := fs.String("team", "", "team key, name, or ID (default: all teams)")
+	mine := fs.Bool("mine", false, "only issues assigned to me")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	ctx := context.Background()
+	teamID, err := resolveTeam(ctx, client, *team)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	query := api.IssueQuery{TeamID: teamID}
+	if *mine {
+		viewer, err := client.GetViewer(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching viewer: %v\n", err)
+			return exitError
+		}
+		query.AssigneeID = viewer.ID
+	}
+
+	issues, err := client.GetAllIssues(ctx, query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading issues: %v\n", err)
+		return exitError
+	}
+
+	for _, issue := range issues {
+		fmt.Printf("%s\t%s\t%s\n", issue.Identifier, tsvEscaper.Replace(issue.Title), issue.BranchName)
+	}
+	return exitOK
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+	"lazylinear/internal/server"
+)
+
+// defaultSocket is where `lazylinear serve` listens unless --socket is given
+func defaultSocket() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".lazylinear", "lazylinear.sock")
+}
+
+// runServe keeps every team's issues loaded and answers JSON-RPC requests
+// about them on a Unix socket until interrupted, e.g.
+//
+//	echo '{"jsonrpc":"2.0","id":1,"method":"issues.mine"}' | nc -U ~/.lazylinear/lazylinear.sock
+func runServe(args []string, client *api.Client, cfg *config.Config) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	socket := fs.String("socket", defaultSocket(), "Unix socket to listen on")
+	refresh := fs.Duration("refresh", 5*time.Minute, "how often to reload issues; 0 disables")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if *socket == "" {
+		fmt.Fprintln(os.Stderr, "--socket is required")
+		return exitError
+	}
+
+	srv := server.New(client, nil)
+	if err := srv.Refresh(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	listener, err := listenUnix(*socket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listening on %s: %v\n", *socket, err)
+		return exitError
+	}
+	defer os.Remove(*socket)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		listener.Close()
+	}()
+	if *refresh > 0 {
+		go func() {
+			for range time.Tick(*refresh) {
+				if err := srv.Refresh(context.Background()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error refreshing: %v\n", err)
+				}
+			}
+		}()
+	}
+
+	fmt.Fprintf(os.Stderr, "Serving on %s\n", *socket)
+	if err := srv.Serve(listener); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// listenUnix listens on a socket only the current user can use, replacing
+// a stale socket file left by a server that did not shut down cleanly
+func listenUnix(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if info, err := os.Lstat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is already running")
+		}
+		// Only a stale socket is ours to replace
+		if info.Mode().Type() != os.ModeSocket {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		os.Remove(path)
+	}
+	// The socket is created private, leaving no window before the chmod
+	restore := restrictUmask()
+	listener, err := net.Listen("unix", path)
+	restore()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
@@ -0,0 +1,12 @@
+//go:build unix
+
+package cli
+
+import "syscall"
+
+// restrictUmask keeps files created until the returned func is called
+// private to the user
+func restrictUmask() (restore func()) {
+	old := syscall.Umask(0077)
+	return func() { syscall.Umask(old) }
+}
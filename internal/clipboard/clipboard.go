@@ -0,0 +1,119 @@
+// Package clipboard copies text to the system clipboard, picking a helper
+// program for the current platform and falling back to the OSC 52 terminal
+// escape sequence when none is available or the session is remote.
+package clipboard
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// OSC52 is the command value that forces the terminal escape sequence
+const OSC52 = "osc52"
+
+// ErrUnavailable is returned when no clipboard mechanism could be used
+var ErrUnavailable = errors.New("no clipboard available")
+
+// Copy places text on the clipboard. A non-empty command overrides helper
+// detection: it runs through the shell with the text on stdin, or uses the
+// terminal escape sequence if it is "osc52".
+func Copy(command, text string) error {
+	switch {
+	case command == OSC52:
+		return copyOSC52(text)
+	case command != "":
+		name, args := shell(runtime.GOOS, command)
+		return run(name, args, text)
+	case remote(os.Getenv):
+		// A local helper would fill the remote machine's clipboard
+		return copyOSC52(text)
+	}
+	for _, helper := range helpers(runtime.GOOS, os.Getenv) {
+		if _, err := exec.LookPath(helper[0]); err == nil {
+			return run(helper[0], helper[1:], text)
+		}
+	}
+	return copyOSC52(text)
+}
+
+// remote reports whether the session runs over SSH
+func remote(getenv func(string) string) bool {
+	return getenv("SSH_TTY") != "" || getenv("SSH_CONNECTION") != ""
+}
+
+// helpers lists candidate clipboard programs for the platform in preference order
+func helpers(goos string, getenv func(string) string) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip.exe"}, {"powershell.exe", "-NoProfile", "-Command", "$input | Set-Clipboard"}}
+	}
+
+	var helpers [][]string
+	if getenv("WAYLAND_DISPLAY") != "" {
+		helpers = append(helpers, []string{"wl-copy"})
+	}
+	if getenv("DISPLAY") != "" {
+		helpers = append(helpers, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+	}
+	// WSL can reach the Windows clipboard, and Termux has its own helper
+	return append(helpers, []string{"clip.exe"}, []string{"termux-clipboard-set"})
+}
+
+// shell returns the command line that runs a user-supplied command
+func shell(goos, command string) (string, []string) {
+	if goos == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
+
+// run runs a program with input on stdin. Output is discarded rather than
+// captured because helpers such as xclip keep running in the background.
+func run(name string, args []string, input string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// copyOSC52 asks the terminal to set the clipboard
+func copyOSC52(text string) error {
+	out, err := openTerminal()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer out.Close()
+	_, err = io.WriteString(out, osc52Sequence(text, os.Getenv))
+	return err
+}
+
+// osc52Sequence builds the escape sequence that sets the clipboard to text,
+// wrapped for tmux and screen so it reaches the outer terminal
+func osc52Sequence(text string, getenv func(string) string) string {
+	seq := "\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	switch {
+	case getenv("TMUX") != "":
+		return "\033Ptmux;" + strings.ReplaceAll(seq, "\033", "\033\033") + "\033\\"
+	case strings.HasPrefix(getenv("TERM"), "screen"):
+		return "\033P" + seq + "\033\\"
+	}
+	return seq
+}
+
+// openTerminal opens the controlling terminal for writing escape sequences
+func openTerminal() (io.WriteCloser, error) {
+	if runtime.GOOS == "windows" {
+		return os.OpenFile("CONOUT$", os.O_WRONLY, 0)
+	}
+	return os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+}
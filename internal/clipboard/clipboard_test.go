@@ -0,0 +1,103 @@
+package clipboard
+
+import (
+	"reflect"
+	"testing"
+)
+
+// env returns a getenv backed by vars
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		vars map[string]string
+		want []string
+	}{
+		{"darwin", "darwin", nil, []string{"pbcopy"}},
+		{"windows", "windows", nil, []string{"clip.exe", "powershell.exe"}},
+		{"wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"wl-copy", "clip.exe", "termux-clipboard-set"}},
+		{"x11", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xclip", "xsel", "clip.exe", "termux-clipboard-set"}},
+		{"xwayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip", "xsel", "clip.exe", "termux-clipboard-set"}},
+		{"headless", "linux", nil, []string{"clip.exe", "termux-clipboard-set"}},
+		{"bsd", "freebsd", map[string]string{"DISPLAY": ":0"}, []string{"xclip", "xsel", "clip.exe", "termux-clipboard-set"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, helper := range helpers(tt.goos, env(tt.vars)) {
+				got = append(got, helper[0])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("helpers(%q) = %v, want %v", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSC52Sequence(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		vars map[string]string
+		want string
+	}{
+		{"plain", "hello", nil, "\033]52;c;aGVsbG8=\a"},
+		{"empty", "", nil, "\033]52;c;\a"},
+		{"unicode", "ENG-1 ✓", nil, "\033]52;c;RU5HLTEg4pyT\a"},
+		{"tmux", "hello", map[string]string{"TMUX": "/tmp/tmux-1000/default,1,0", "TERM": "screen-256color"}, "\033Ptmux;\033\033]52;c;aGVsbG8=\a\033\\"},
+		{"screen", "hello", map[string]string{"TERM": "screen.xterm-256color"}, "\033P\033]52;c;aGVsbG8=\a\033\\"},
+		{"xterm", "hello", map[string]string{"TERM": "xterm-256color"}, "\033]52;c;aGVsbG8=\a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := osc52Sequence(tt.text, env(tt.vars)); got != tt.want {
+				t.Errorf("osc52Sequence(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]string
+		want bool
+	}{
+		{"local", nil, false},
+		{"ssh tty", map[string]string{"SSH_TTY": "/dev/pts/0"}, true},
+		{"ssh connection", map[string]string{"SSH_CONNECTION": "10.0.0.1 50000 10.0.0.2 22"}, true},
+		{"unrelated", map[string]string{"DISPLAY": ":0"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remote(env(tt.vars)); got != tt.want {
+				t.Errorf("remote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShell(t *testing.T) {
+	tests := []struct {
+		goos     string
+		command  string
+		wantName string
+		wantArgs []string
+	}{
+		{"linux", "tmux load-buffer -", "sh", []string{"-c", "tmux load-buffer -"}},
+		{"darwin", "pbcopy", "sh", []string{"-c", "pbcopy"}},
+		{"windows", "clip.exe", "cmd", []string{"/C", "clip.exe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := shell(tt.goos, tt.command)
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("shell(%q, %q) = %s %v, want %s %v", tt.goos, tt.command, name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
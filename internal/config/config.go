@@ -9,6 +9,149 @@ import (
 // Config represents the application configuration
 type Config struct {
 	APIKey string `json:"api_key"`
+	// APIURL overrides the GraphQL endpoint (default
+	// https://api.linear.app/graphql), e.g. for a proxy or a mock server
+	APIURL string `json:"api_url,omitempty"`
+	// StateAliases maps custom workflow state names to the canonical
+	// view tabs, e.g. "Code Review" -> "In Review"
+	StateAliases map[string]string `json:"state_aliases,omitempty"`
+	// DefaultTeam selects the team shown at startup by key, name, or ID
+	DefaultTeam string `json:"default_team,omitempty"`
+	// Teams restricts the team bar to these team keys or names
+	Teams []string `json:"teams,omitempty"`
+	// HiddenTeams removes these team keys or names from the team bar
+	HiddenTeams []string `json:"hidden_teams,omitempty"`
+	// AssignedToMe starts the TUI filtered to issues assigned to the viewer
+	AssignedToMe bool `json:"assigned_to_me,omitempty"`
+	// RefreshInterval automatically refreshes issues every N minutes (0 disables)
+	RefreshInterval int `json:"refresh_interval,omitempty"`
+	// PreviewOnCursor makes the details pane follow the list cursor
+	PreviewOnCursor bool `json:"preview_on_cursor,omitempty"`
+	// DisablePrefetch turns off background fetching of other teams' issues
+	DisablePrefetch bool `json:"disable_prefetch,omitempty"`
+	// StaleAfter flags loaded issues as stale after N minutes (default 10)
+	StaleAfter int `json:"stale_after,omitempty"`
+	// AgingDays badges unresolved issues not updated in N days (default 14)
+	AgingDays int `json:"aging_days,omitempty"`
+	// ListWidth is the issue list width as a percentage of the screen (default 40)
+	ListWidth int `json:"list_width,omitempty"`
+	// ZoomDetails shows the details pane full-screen
+	ZoomDetails bool `json:"zoom_details,omitempty"`
+	// Theme selects the color theme: default, light, or mono
+	Theme string `json:"theme,omitempty"`
+	// ThemeColors overrides the theme's state colors by state name, plus
+	// "urgent" for the urgent-priority marker. Values are color names such as
+	// "blue" or "bright-red", 256-color indexes, "#rrggbb", or "none".
+	ThemeColors map[string]string `json:"theme_colors,omitempty"`
+	// IssueLint holds the rules new issues are checked against before creation
+	IssueLint LintRules `json:"issue_lint,omitempty"`
+	// PlainEmoji disables rendering :shortcode: emoji as Unicode
+	PlainEmoji bool `json:"plain_emoji,omitempty"`
+	// DefaultView selects the view tab shown at startup, e.g. "In Progress"
+	DefaultView string `json:"default_view,omitempty"`
+	// ClipboardCommand overrides clipboard detection with a shell command that
+	// reads the text on stdin, such as "clip.exe" under WSL or
+	// "tmux load-buffer -", or "osc52" to copy through the terminal. When
+	// empty, the platform's helpers are detected automatically.
+	ClipboardCommand string `json:"clipboard_command,omitempty"`
+	// Alert rings the bell and/or flashes the status bar when background
+	// sync finds changes to your issues or new mentions: off, bell, flash, or both
+	Alert string `json:"alert,omitempty"`
+	// DateFormat is the Go time layout for timestamps older than a week,
+	// which are otherwise shown relative like "3h ago" (default 2006-01-02)
+	DateFormat string `json:"date_format,omitempty"`
+	// BranchTemplate overrides Linear's generated branch name for the copy
+	// and checkout actions, e.g. "{user}/{identifier}-{slug}". Placeholders:
+	// {user}, {identifier}, {team}, {number}, and {slug} (the title)
+	BranchTemplate string `json:"branch_template,omitempty"`
+	// Repos maps project names or team keys to local repository paths, so
+	// branch and pull request actions run in the right repository wherever
+	// lazylinear was launched; a project mapping wins over its team's
+	Repos map[string]string `json:"repos,omitempty"`
+	// StartOnBranch moves an issue to In Progress and assigns it to you
+	// after checking out its branch from the TUI
+	StartOnBranch bool `json:"start_on_branch,omitempty"`
+	// FocusMinutes is the length of a focus timer session (default 25)
+	FocusMinutes int `json:"focus_minutes,omitempty"`
+	// FocusLog records finished focus sessions: file (the default, appending
+	// to ~/.lazylinear/timelog.csv), comment (posting "Worked 25m" on the
+	// issue), both, or off
+	FocusLog string `json:"focus_log,omitempty"`
+	// TimeTracker runs shell commands to start and stop an external time
+	// tracker such as Toggl, Harvest, or watson when you start and stop work
+	TimeTracker TrackerHooks `json:"time_tracker,omitempty"`
+	// TerminalTitle titles the terminal window, and the tmux window inside
+	// tmux, after the selected issue, e.g. "{identifier} {title}".
+	// Placeholders: {identifier}, {title}, {state}, and {team}. Empty leaves
+	// the title alone.
+	TerminalTitle string `json:"terminal_title,omitempty"`
+	// VaultDir is the directory, such as an Obsidian vault, that issues are
+	// exported to as markdown notes
+	VaultDir string `json:"vault_dir,omitempty"`
+	// SlackWebhooks maps channel names to Slack incoming webhook URLs that
+	// the selected issue can be sent to, e.g. {"#eng-help": "https://hooks.slack.com/..."}
+	SlackWebhooks map[string]string `json:"slack_webhooks,omitempty"`
+	// LLM configures the optional language model that summarizes issues
+	LLM LLMConfig `json:"llm,omitempty"`
+	// Hooks are shell commands run on events, for integrations such as tmux
+	// titles, status bars, or loggers
+	Hooks EventHooks `json:"hooks,omitempty"`
+	// Columns lists the issue list columns shown before the title: identifier,
+	// assignee, priority, estimate, labels, project, team, updated (default
+	// identifier and assignee; the All teams list adds team)
+	Columns []string `json:"columns,omitempty"`
+	// CAFile is a PEM bundle of extra certificate authorities to trust, for
+	// corporate networks that intercept TLS. Proxies are taken from the
+	// HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables.
+	CAFile string `json:"ca_file,omitempty"`
+}
+
+// TrackerHooks are the shell commands driving an external time tracker. They
+// see the issue as $LAZYLINEAR_IDENTIFIER, $LAZYLINEAR_TITLE,
+// $LAZYLINEAR_URL, and $LAZYLINEAR_TEAM.
+type TrackerHooks struct {
+	// Start runs when you start an issue or a focus timer on it, e.g.
+	// `watson start linear +"$LAZYLINEAR_IDENTIFIER"`
+	Start string `json:"start,omitempty"`
+	// Stop runs when the focus timer ends or work moves to another issue,
+	// e.g. "watson stop"
+	Stop string `json:"stop,omitempty"`
+}
+
+// EventHooks are shell commands run in the background on UI events. Issue
+// events pass the issue as JSON on stdin and as the environment variables
+// of TrackerHooks, plus $LAZYLINEAR_EVENT.
+type EventHooks struct {
+	// OnSelect runs when a different issue is selected
+	OnSelect string `json:"on_select,omitempty"`
+	// OnStateChange runs when a loaded issue changes state, by your hand or
+	// another's, with the old state in $LAZYLINEAR_PREVIOUS_STATE
+	OnStateChange string `json:"on_state_change,omitempty"`
+	// OnRefresh runs after the issue list is reloaded, with the loaded
+	// issues as a JSON array on stdin
+	OnRefresh string `json:"on_refresh,omitempty"`
+}
+
+// LLMConfig selects a language model: a local command, or an
+// OpenAI-compatible endpoint. The command wins when both are set.
+type LLMConfig struct {
+	// Command reads the prompt on stdin and writes the reply to stdout,
+	// e.g. "llm -m gpt-4o-mini" or "ollama run llama3"
+	Command string `json:"command,omitempty"`
+	// Endpoint is the API base URL, e.g. https://api.openai.com/v1
+	Endpoint string `json:"endpoint,omitempty"`
+	// Model is the model name sent to the endpoint
+	Model string `json:"model,omitempty"`
+	// APIKey authenticates with the endpoint; when empty, $OPENAI_API_KEY is used
+	APIKey string `json:"api_key,omitempty"`
+}
+
+// LintRules describes filing conventions enforced when creating issues
+type LintRules struct {
+	// TitlePattern is a regular expression the title must match
+	TitlePattern string `json:"title_pattern,omitempty"`
+	// MinDescriptionLength is the minimum description length in characters
+	MinDescriptionLength int `json:"min_description_length,omitempty"`
 }
 
 // Load loads configuration from file
@@ -44,18 +187,52 @@ func (c *Config) Save() error {
 		return err
 	}
 
+	// The config holds the API key, so only the user may read it
 	configDir := filepath.Join(home, ".lazylinear")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		return err
+	}
+	if err := os.Chmod(configDir, 0o700); err != nil {
 		return err
 	}
 
 	configPath := filepath.Join(configDir, "config.json")
 
-	file, err := os.Create(configPath)
+	// Write a private temporary file and rename it over the config, so an
+	// interrupted save never leaves a truncated file behind
+	file, err := os.CreateTemp(configDir, "config-*.json")
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer os.Remove(file.Name())
+	if err := json.NewEncoder(file).Encode(c); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := backupUnparseable(configPath); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), configPath)
+}
 
-	return json.NewEncoder(file).Encode(c)
+// backupUnparseable moves a config file that Load would reject aside to
+// config.json.bak, so saving settings never destroys a hand-edited file
+func backupUnparseable(configPath string) error {
+	file, err := os.Open(configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var config Config
+	err = json.NewDecoder(file).Decode(&config)
+	file.Close()
+	if err == nil {
+		return nil
+	}
+	return os.Rename(configPath, configPath+".bak")
 }
@@ -0,0 +1,218 @@
+package emoji
+
+import (
+	"regexp"
+)
+
+// shortcodePattern matches :shortcode: sequences
+var shortcodePattern = regexp.MustCompile(`:([a-z0-9_+\-]+):`)
+
+// shortcodes maps common shortcodes to their Unicode emoji
+var shortcodes = map[string]string{
+	"+1":                          "👍",
+	"thumbsup":                    "👍",
+	"-1":                          "👎",
+	"thumbsdown":                  "👎",
+	"smile":                       "😄",
+	"smiley":                      "😃",
+	"grinning":                    "😀",
+	"laughing":                    "😆",
+	"joy":                         "😂",
+	"sweat_smile":                 "😅",
+	"slightly_smiling_face":       "🙂",
+	"wink":                        "😉",
+	"blush":                       "😊",
+	"heart_eyes":                  "😍",
+	"thinking":                    "🤔",
+	"thinking_face":               "🤔",
+	"neutral_face":                "😐",
+	"expressionless":              "😑",
+	"unamused":                    "😒",
+	"roll_eyes":                   "🙄",
+	"grimacing":                   "😬",
+	"confused":                    "😕",
+	"disappointed":                "😞",
+	"worried":                     "😟",
+	"cry":                         "😢",
+	"sob":                         "😭",
+	"scream":                      "😱",
+	"rage":                        "😡",
+	"exploding_head":              "🤯",
+	"sunglasses":                  "😎",
+	"nerd_face":                   "🤓",
+	"upside_down_face":            "🙃",
+	"facepalm":                    "🤦",
+	"shrug":                       "🤷",
+	"pray":                        "🙏",
+	"clap":                        "👏",
+	"wave":                        "👋",
+	"raised_hands":                "🙌",
+	"muscle":                      "💪",
+	"ok_hand":                     "👌",
+	"point_right":                 "👉",
+	"point_left":                  "👈",
+	"point_up":                    "☝️",
+	"point_down":                  "👇",
+	"eyes":                        "👀",
+	"brain":                       "🧠",
+	"heart":                       "❤️",
+	"broken_heart":                "💔",
+	"fire":                        "🔥",
+	"sparkles":                    "✨",
+	"star":                        "⭐",
+	"zap":                         "⚡",
+	"boom":                        "💥",
+	"tada":                        "🎉",
+	"confetti_ball":               "🎊",
+	"rocket":                      "🚀",
+	"bug":                         "🐛",
+	"ghost":                       "👻",
+	"robot":                       "🤖",
+	"100":                         "💯",
+	"white_check_mark":            "✅",
+	"heavy_check_mark":            "✔️",
+	"ballot_box_with_check":       "☑️",
+	"x":                           "❌",
+	"negative_squared_cross_mark": "❎",
+	"warning":                     "⚠️",
+	"no_entry":                    "⛔",
+	"no_entry_sign":               "🚫",
+	"stop_sign":                   "🛑",
+	"question":                    "❓",
+	"exclamation":                 "❗",
+	"bangbang":                    "‼️",
+	"red_circle":                  "🔴",
+	"large_orange_circle":         "🟠",
+	"large_yellow_circle":         "🟡",
+	"large_green_circle":          "🟢",
+	"large_blue_circle":           "🔵",
+	"white_circle":                "⚪",
+	"black_circle":                "⚫",
+	"lock":                        "🔒",
+	"unlock":                      "🔓",
+	"key":                         "🔑",
+	"link":                        "🔗",
+	"memo":                        "📝",
+	"pencil":                      "📝",
+	"pencil2":                     "✏️",
+	"book":                        "📖",
+	"books":                       "📚",
+	"bookmark":                    "🔖",
+	"pushpin":                     "📌",
+	"paperclip":                   "📎",
+	"calendar":                    "📆",
+	"date":                        "📅",
+	"clock":                       "🕐",
+	"hourglass":                   "⌛",
+	"alarm_clock":                 "⏰",
+	"stopwatch":                   "⏱️",
+	"chart_with_upwards_trend":    "📈",
+	"chart_with_downwards_trend":  "📉",
+	"bar_chart":                   "📊",
+	"mag":                         "🔍",
+	"bulb":                        "💡",
+	"gear":                        "⚙️",
+	"wrench":                      "🔧",
+	"hammer":                      "🔨",
+	"hammer_and_wrench":           "🛠️",
+	"package":                     "📦",
+	"truck":                       "🚚",
+	"construction":                "🚧",
+	"recycle":                     "♻️",
+	"art":                         "🎨",
+	"lipstick":                    "💄",
+	"lightning":                   "🌩️",
+	"rotating_light":              "🚨",
+	"bell":                        "🔔",
+	"mega":                        "📣",
+	"loudspeaker":                 "📢",
+	"speech_balloon":              "💬",
+	"thought_balloon":             "💭",
+	"email":                       "📧",
+	"envelope":                    "✉️",
+	"inbox_tray":                  "📥",
+	"outbox_tray":                 "📤",
+	"computer":                    "💻",
+	"iphone":                      "📱",
+	"globe_with_meridians":        "🌐",
+	"earth_americas":              "🌎",
+	"lady_beetle":                 "🐞",
+	"snail":                       "🐌",
+	"turtle":                      "🐢",
+	"coffee":                      "☕",
+	"beer":                        "🍺",
+	"pizza":                       "🍕",
+	"cake":                        "🍰",
+	"trophy":                      "🏆",
+	"medal":                       "🏅",
+	"dart":                        "🎯",
+	"crystal_ball":                "🔮",
+	"money_with_wings":            "💸",
+	"moneybag":                    "💰",
+	"arrow_up":                    "⬆️",
+	"arrow_down":                  "⬇️",
+	"arrow_right":                 "➡️",
+	"arrow_left":                  "⬅️",
+	"arrows_counterclockwise":     "🔄",
+	"repeat":                      "🔁",
+	"heavy_plus_sign":             "➕",
+	"heavy_minus_sign":            "➖",
+	"new":                         "🆕",
+	"free":                        "🆓",
+	"sos":                         "🆘",
+	"ok":                          "🆗",
+	"cool":                        "🆒",
+	"skull":                       "💀",
+	"poop":                        "💩",
+	"hankey":                      "💩",
+	"see_no_evil":                 "🙈",
+	"hear_no_evil":                "🙉",
+	"speak_no_evil":               "🙊",
+	"sunny":                       "☀️",
+	"cloud":                       "☁️",
+	"umbrella":                    "☂️",
+	"snowflake":                   "❄️",
+	"rainbow":                     "🌈",
+	"seedling":                    "🌱",
+	"evergreen_tree":              "🌲",
+	"cactus":                      "🌵",
+	"herb":                        "🌿",
+	"fallen_leaf":                 "🍂",
+	"party_popper":                "🎉",
+	"handshake":                   "🤝",
+	"crossed_fingers":             "🤞",
+	"v":                           "✌️",
+	"metal":                       "🤘",
+	"writing_hand":                "✍️",
+	"nail_care":                   "💅",
+	"running":                     "🏃",
+	"walking":                     "🚶",
+	"man_shrugging":               "🤷‍♂️",
+	"woman_shrugging":             "🤷‍♀️",
+	"man_facepalming":             "🤦‍♂️",
+	"woman_facepalming":           "🤦‍♀️",
+	"zzz":                         "💤",
+	"sweat_drops":                 "💦",
+	"droplet":                     "💧",
+	"ocean":                       "🌊",
+	"volcano":                     "🌋",
+	"mountain":                    "⛰️",
+	"flag":                        "🚩",
+	"triangular_flag_on_post":     "🚩",
+	"checkered_flag":              "🏁",
+	"white_flag":                  "🏳️",
+	"hourglass_flowing_sand":      "⏳",
+	"shipit":                      "🐿️",
+	"squirrel":                    "🐿️",
+}
+
+// Render replaces known :shortcode: sequences with Unicode emoji,
+// leaving unknown shortcodes (such as custom workspace emoji) untouched
+func Render(s string) string {
+	return shortcodePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if emoji, ok := shortcodes[match[1:len(match)-1]]; ok {
+			return emoji
+		}
+		return match
+	})
+}
@@ -0,0 +1,110 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"lazylinear/internal/api"
+)
+
+// columns are the fields written for each issue, in order
+var columns = []string{"identifier", "title", "state", "assignee", "priority", "url"}
+
+// row returns the exported field values of an issue
+func row(issue api.Issue) []string {
+	return []string{issue.Identifier, issue.Title, issue.State.Name, issue.Assignee.Name, issue.PriorityLabel(), issue.URL}
+}
+
+// cellEscaper keeps a value inside its markdown table cell
+var cellEscaper = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ")
+
+// Markdown writes issues as a markdown table, linking each identifier to its issue
+func Markdown(w io.Writer, issues []api.Issue) error {
+	headers := []string{"Issue", "Title", "State", "Assignee", "Priority"}
+	if _, err := fmt.Fprintf(w, "| %s |\n|%s\n", strings.Join(headers, " | "), strings.Repeat(" --- |", len(headers))); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		id := issue.Identifier
+		if issue.URL != "" {
+			id = fmt.Sprintf("[%s](%s)", issue.Identifier, issue.URL)
+		}
+		cells := []string{id, issue.Title, issue.State.Name, issue.Assignee.Name, issue.PriorityLabel()}
+		for i, cell := range cells {
+			cells[i] = cellEscaper.Replace(cell)
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSV writes issues as CSV with a header row
+func CSV(w io.Writer, issues []api.Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := cw.Write(row(issue)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Note writes an issue as a markdown note for a local vault such as Obsidian:
+// YAML front matter with the issue's metadata, then the description and the
+// comments, oldest first
+func Note(w io.Writer, issue api.Issue) error {
+	labels := make([]string, len(issue.Labels.Nodes))
+	for i, label := range issue.Labels.Nodes {
+		labels[i] = strconv.Quote(label.Name)
+	}
+	fields := [][2]string{
+		{"identifier", issue.Identifier},
+		{"title", issue.Title},
+		{"state", issue.State.Name},
+		{"assignee", issue.Assignee.Name},
+		{"priority", issue.PriorityLabel()},
+		{"team", issue.Team.Key},
+		{"project", issue.Project.Name},
+		{"due", issue.DueDate},
+		{"url", issue.URL},
+		{"created", issue.CreatedAt},
+		{"updated", issue.UpdatedAt},
+	}
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, field := range fields {
+		if field[1] != "" {
+			// Double-quoted Go strings are valid YAML scalars
+			fmt.Fprintf(&b, "%s: %s\n", field[0], strconv.Quote(field[1]))
+		}
+	}
+	fmt.Fprintf(&b, "labels: [%s]\n", strings.Join(labels, ", "))
+	b.WriteString("---\n\n")
+
+	fmt.Fprintf(&b, "# %s %s\n\n", issue.Identifier, issue.Title)
+	if description := strings.TrimSpace(issue.Description); description != "" {
+		b.WriteString(description + "\n")
+	}
+
+	comments := append([]api.Comment(nil), issue.Comments.Nodes...)
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].CreatedAt < comments[j].CreatedAt })
+	if len(comments) > 0 {
+		b.WriteString("\n## Comments\n")
+	}
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "\n### %s — %s\n\n%s\n", comment.User.Name, comment.CreatedAt, strings.TrimSpace(comment.Body))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
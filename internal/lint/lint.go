@@ -0,0 +1,30 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"lazylinear/internal/config"
+)
+
+// Check validates a new issue against the configured lint rules and returns
+// a human-readable warning for each rule it violates
+func Check(rules config.LintRules, title, description string) []string {
+	var warnings []string
+
+	if rules.TitlePattern != "" {
+		re, err := regexp.Compile(rules.TitlePattern)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid title_pattern %q: %v", rules.TitlePattern, err))
+		} else if !re.MatchString(title) {
+			warnings = append(warnings, fmt.Sprintf("title does not match %s", rules.TitlePattern))
+		}
+	}
+
+	if length := len([]rune(strings.TrimSpace(description))); length < rules.MinDescriptionLength {
+		warnings = append(warnings, fmt.Sprintf("description is %d characters, minimum is %d", length, rules.MinDescriptionLength))
+	}
+
+	return warnings
+}
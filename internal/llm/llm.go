@@ -0,0 +1,124 @@
+// Package llm sends prompts to a language model, either through a local
+// command or an OpenAI-compatible chat completions endpoint.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotConfigured is returned when neither a command nor an endpoint is set
+var ErrNotConfigured = errors.New("no LLM command or endpoint configured")
+
+// Client sends prompts to the configured model. A Command wins over an
+// Endpoint when both are set.
+type Client struct {
+	// Command is a shell command that reads the prompt on stdin and writes
+	// the reply to stdout, e.g. "llm -m gpt-4o-mini" or "ollama run llama3"
+	Command string
+	// Endpoint is the base URL of an OpenAI-compatible API, e.g.
+	// https://api.openai.com/v1 or http://localhost:11434/v1
+	Endpoint string
+	// Model is the model name sent to the endpoint
+	Model string
+	// APIKey authenticates with the endpoint, if it needs a key
+	APIKey string
+}
+
+// Configured reports whether prompts can be sent
+func (c Client) Configured() bool {
+	return c.Command != "" || c.Endpoint != ""
+}
+
+// Complete sends a system instruction and a prompt and returns the reply
+func (c Client) Complete(ctx context.Context, system, prompt string) (string, error) {
+	switch {
+	case c.Command != "":
+		return c.runCommand(ctx, system+"\n\n"+prompt)
+	case c.Endpoint != "":
+		return c.chat(ctx, system, prompt)
+	}
+	return "", ErrNotConfigured
+}
+
+// runCommand pipes the prompt through the configured command
+func (c Client) runCommand(ctx context.Context, prompt string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Command)
+	cmd.Env = os.Environ()
+	cmd.Stdin = strings.NewReader(prompt)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// message is a chat message in the chat completions API
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chat calls the endpoint's chat completions API
+func (c Client) chat(ctx context.Context, system, prompt string) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"model": c.Model,
+		"messages": []message{
+			{Role: "system", Content: system},
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	url := strings.TrimRight(c.Endpoint, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Choices []struct {
+			Message message `json:"message"`
+		} `json:"choices"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if result.Error != nil {
+		return "", errors.New(result.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK || len(result.Choices) == 0 {
+		return "", fmt.Errorf("%s: no reply", resp.Status)
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
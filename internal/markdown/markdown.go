@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ANSI styles understood by the terminal UI
+const (
+	reset     = "\033[0m"
+	bold      = "\033[1m"
+	underline = "\033[4m"
+	cyan      = "\033[36m"
+	blue      = "\033[34m"
+	magenta   = "\033[35m"
+	dim       = "\033[90m"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletPattern    = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedPattern   = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	checkboxPattern  = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	rulePattern      = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	codeSpanPattern  = regexp.MustCompile("`([^`]+)`")
+	boldPattern      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicPattern    = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	imagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	ansiPattern      = regexp.MustCompile(`\033\[[0-9;]*m`)
+	codeSpanSentinel = "\x00"
+)
+
+// Render converts markdown into ANSI-styled text wrapped to width display
+// columns. A width of zero or less disables wrapping.
+func Render(src string, width int) string {
+	var out strings.Builder
+	inCode := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out.WriteString("  " + cyan + line + reset + "\n")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			out.WriteString("\n")
+		case rulePattern.MatchString(line):
+			out.WriteString(dim + strings.Repeat("─", ruleWidth(width)) + reset + "\n")
+		case headingPattern.MatchString(trimmed):
+			m := headingPattern.FindStringSubmatch(trimmed)
+			style := bold + magenta
+			if len(m[1]) == 1 {
+				style += underline
+			}
+			writeWrapped(&out, style+inline(m[2])+reset, "", "", width)
+		case strings.HasPrefix(trimmed, ">"):
+			text := strings.TrimSpace(strings.TrimLeft(trimmed, ">"))
+			writeWrapped(&out, dim+inline(text)+reset, dim+"│ "+reset, dim+"│ "+reset, width)
+		case bulletPattern.MatchString(line):
+			m := bulletPattern.FindStringSubmatch(line)
+			indent := strings.Repeat(" ", len(m[1]))
+			marker := "• "
+			text := m[2]
+			if c := checkboxPattern.FindStringSubmatch(text); c != nil {
+				marker = "☐ "
+				if c[1] != " " {
+					marker = "☑ "
+				}
+				text = c[2]
+			}
+			writeWrapped(&out, inline(text), indent+marker, indent+"  ", width)
+		case orderedPattern.MatchString(line):
+			m := orderedPattern.FindStringSubmatch(line)
+			indent := strings.Repeat(" ", len(m[1]))
+			marker := m[2] + ". "
+			writeWrapped(&out, inline(m[3]), indent+marker, indent+strings.Repeat(" ", len(marker)), width)
+		default:
+			writeWrapped(&out, inline(trimmed), "", "", width)
+		}
+	}
+
+	return strings.TrimRight(out.String(), "\n")
+}
+
+// inline applies styles for code spans, emphasis, images, and links
+func inline(text string) string {
+	// Protect code spans from further formatting
+	var spans []string
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(m string) string {
+		spans = append(spans, cyan+m[1:len(m)-1]+reset)
+		return codeSpanSentinel
+	})
+
+	text = imagePattern.ReplaceAllString(text, "[image: $1]")
+	text = linkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := linkPattern.FindStringSubmatch(m)
+		if parts[1] == parts[2] {
+			return blue + underline + parts[2] + reset
+		}
+		return blue + underline + parts[1] + reset + dim + " (" + parts[2] + ")" + reset
+	})
+	text = boldPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return bold + m[2:len(m)-2] + reset
+	})
+	text = italicPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return m[1 : len(m)-1]
+	})
+
+	for _, span := range spans {
+		text = strings.Replace(text, codeSpanSentinel, span, 1)
+	}
+	return text
+}
+
+// writeWrapped word-wraps styled text to width, prefixing the first line with
+// first and continuation lines with rest
+func writeWrapped(out *strings.Builder, text, first, rest string, width int) {
+	avail := width - VisibleWidth(first)
+	if width <= 0 || avail < 10 {
+		out.WriteString(first + text + "\n")
+		return
+	}
+
+	prefix := first
+	var line strings.Builder
+	lineWidth := 0
+	for _, word := range strings.Fields(text) {
+		w := VisibleWidth(word)
+		if lineWidth > 0 && lineWidth+1+w > avail {
+			out.WriteString(prefix + line.String() + "\n")
+			prefix = rest
+			line.Reset()
+			lineWidth = 0
+		}
+		if lineWidth > 0 {
+			line.WriteString(" ")
+			lineWidth++
+		}
+		line.WriteString(word)
+		lineWidth += w
+	}
+	out.WriteString(prefix + line.String() + "\n")
+}
+
+// VisibleWidth returns the display width of s, ignoring ANSI escape sequences
+func VisibleWidth(s string) int {
+	return runewidth.StringWidth(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// Truncate shortens s to at most width display columns, ending it with an
+// ellipsis when cut. ANSI escape sequences are kept intact and do not count
+// toward the width.
+func Truncate(s string, width int) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	used := 0
+	for i := 0; i < len(s); {
+		if loc := ansiPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			out.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runewidth.RuneWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		out.WriteRune(r)
+		used += w
+		i += size
+	}
+	return out.String() + "…" + reset
+}
+
+// ruleWidth returns the width of a horizontal rule
+func ruleWidth(width int) int {
+	if width <= 0 || width > 80 {
+		return 40
+	}
+	return width
+}
@@ -0,0 +1,56 @@
+package script
+
+import (
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"lazylinear/internal/api"
+)
+
+// issueValue converts an issue into the struct scripts see. Its fields are
+// id, identifier, title, description, url, state, state_id, priority (0 for
+// none, then 1 urgent to 4 low), estimate (None when unestimated), assignee
+// and assignee_id ("" when unassigned), labels, team, team_id, project,
+// project_progress, parent_id (None for top-level issues), due_date,
+// created_at, updated_at, started_at, completed_at, and canceled_at. Dates
+// are strings, "" when unset. The description is only loaded for the
+// selected issue.
+func issueValue(issue api.Issue) starlark.Value {
+	labels := make([]starlark.Value, len(issue.Labels.Nodes))
+	for i, label := range issue.Labels.Nodes {
+		labels[i] = starlark.String(label.Name)
+	}
+	var estimate starlark.Value = starlark.None
+	if issue.Estimate != nil {
+		estimate = starlark.Float(*issue.Estimate)
+	}
+	var parentID starlark.Value = starlark.None
+	if issue.Parent != nil {
+		parentID = starlark.String(issue.Parent.ID)
+	}
+	return starlarkstruct.FromStringDict(starlark.String("issue"), starlark.StringDict{
+		"id":               starlark.String(issue.ID),
+		"identifier":       starlark.String(issue.Identifier),
+		"title":            starlark.String(issue.Title),
+		"description":      starlark.String(issue.Description),
+		"url":              starlark.String(issue.URL),
+		"state":            starlark.String(issue.State.Name),
+		"state_id":         starlark.String(issue.State.ID),
+		"priority":         starlark.MakeInt(int(issue.Priority)),
+		"estimate":         estimate,
+		"assignee":         starlark.String(issue.Assignee.Name),
+		"assignee_id":      starlark.String(issue.Assignee.ID),
+		"labels":           starlark.Tuple(labels),
+		"team":             starlark.String(issue.Team.Key),
+		"team_id":          starlark.String(issue.Team.ID),
+		"project":          starlark.String(issue.Project.Name),
+		"project_progress": starlark.Float(issue.Project.Progress),
+		"parent_id":        parentID,
+		"due_date":         starlark.String(issue.DueDate),
+		"created_at":       starlark.String(issue.CreatedAt),
+		"updated_at":       starlark.String(issue.UpdatedAt),
+		"started_at":       starlark.String(issue.StartedAt),
+		"completed_at":     starlark.String(issue.CompletedAt),
+		"canceled_at":      starlark.String(issue.CanceledAt),
+	})
+}
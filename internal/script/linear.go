@@ -0,0 +1,216 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"lazylinear/internal/api"
+)
+
+// sessionKey is the thread-local key of the client an action runs with
+const sessionKey = "lazylinear.session"
+
+// session is what the linear module calls the API with during an action
+type session struct {
+	ctx    context.Context
+	client *api.Client
+}
+
+// linear is the module of API calls available to actions. Issues may be
+// passed as issue structs or as IDs or identifiers.
+var linear = &starlarkstruct.Module{
+	Name: "linear",
+	Members: starlark.StringDict{
+		"get_issue":    starlark.NewBuiltin("linear.get_issue", getIssue),
+		"comment":      starlark.NewBuiltin("linear.comment", comment),
+		"set_state":    starlark.NewBuiltin("linear.set_state", setState),
+		"assign":       starlark.NewBuiltin("linear.assign", assign),
+		"archive":      starlark.NewBuiltin("linear.archive", archive),
+		"create_issue": starlark.NewBuiltin("linear.create_issue", createIssue),
+		"members":      starlark.NewBuiltin("linear.members", members),
+	},
+}
+
+// current returns the session of the action running on thread
+func current(thread *starlark.Thread) (*session, error) {
+	s, ok := thread.Local(sessionKey).(*session)
+	if !ok || s.client == nil {
+		return nil, errors.New("the Linear API is only available to actions")
+	}
+	return s, nil
+}
+
+// issueRef is an issue argument: an issue struct, or an ID or identifier
+type issueRef struct {
+	id     string
+	teamID string
+}
+
+func (r *issueRef) Unpack(v starlark.Value) error {
+	switch v := v.(type) {
+	case starlark.String:
+		r.id = string(v)
+		return nil
+	case *starlarkstruct.Struct:
+		id, err := v.Attr("id")
+		if err != nil {
+			return err
+		}
+		s, ok := id.(starlark.String)
+		if !ok {
+			return errors.New("issue id is not a string")
+		}
+		r.id = string(s)
+		if teamID, err := v.Attr("team_id"); err == nil {
+			if s, ok := teamID.(starlark.String); ok {
+				r.teamID = string(s)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("want an issue or issue ID, got %s", v.Type())
+}
+
+func getIssue(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref issueRef
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "issue", &ref); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := s.client.GetIssue(s.ctx, ref.id)
+	if err != nil {
+		return nil, err
+	}
+	return issueValue(*issue), nil
+}
+
+func comment(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref issueRef
+	var body string
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "issue", &ref, "body", &body); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.AddComment(s.ctx, ref.id, body); err != nil {
+		return nil, err
+	}
+	return starlark.None, nil
+}
+
+// setState moves an issue to the team's workflow state with the given name,
+// ignoring case
+func setState(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref issueRef
+	var name string
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "issue", &ref, "state", &name); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	if ref.teamID == "" {
+		issue, err := s.client.GetIssue(s.ctx, ref.id)
+		if err != nil {
+			return nil, err
+		}
+		ref.id, ref.teamID = issue.ID, issue.Team.ID
+	}
+	states, err := s.client.GetTeamStates(s.ctx, ref.teamID)
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		if strings.EqualFold(state.Name, name) {
+			if err := s.client.SetIssueState(s.ctx, ref.id, state.ID); err != nil {
+				return nil, err
+			}
+			return starlark.None, nil
+		}
+	}
+	return nil, fmt.Errorf("no workflow state named %q", name)
+}
+
+// assign sets an issue's assignee by user ID; "" unassigns it
+func assign(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref issueRef
+	var userID string
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "issue", &ref, "user_id", &userID); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.AssignIssue(s.ctx, ref.id, userID); err != nil {
+		return nil, err
+	}
+	return starlark.None, nil
+}
+
+func archive(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref issueRef
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "issue", &ref); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.client.ArchiveIssue(s.ctx, ref.id); err != nil {
+		return nil, err
+	}
+	return starlark.None, nil
+}
+
+func createIssue(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var teamID, title, description string
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "team_id", &teamID, "title", &title, "description?", &description); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := s.client.CreateIssue(s.ctx, teamID, title, description)
+	if err != nil {
+		return nil, err
+	}
+	return issueValue(*issue), nil
+}
+
+// members lists a team's members as structs with id, name, and active
+func members(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var teamID string
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "team_id", &teamID); err != nil {
+		return nil, err
+	}
+	s, err := current(thread)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.client.GetTeamMembers(s.ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]starlark.Value, len(users))
+	for i, user := range users {
+		list[i] = starlarkstruct.FromStringDict(starlark.String("user"), starlark.StringDict{
+			"id":     starlark.String(user.ID),
+			"name":   starlark.String(user.Name),
+			"active": starlark.Bool(user.Active),
+		})
+	}
+	return starlark.NewList(list), nil
+}
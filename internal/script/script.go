@@ -0,0 +1,242 @@
+// Package script runs user Starlark scripts that define computed views of
+// the issue list and custom actions against the Linear API.
+//
+// A script registers views and actions with two builtins:
+//
+//	def urgent(issue):
+//	    return issue.priority in (1, 2) and issue.completed_at == ""
+//
+//	view("Urgent", filter = urgent, key = lambda issue: issue.due_date or "9999")
+//
+//	def triage(issue):
+//	    linear.set_state(issue.id, "Todo")
+//	    linear.comment(issue.id, "Triaged")
+//	    return "Moved %s to Todo" % issue.identifier
+//
+//	action("Z", "Triage", triage)
+//
+// Issues are structs with the fields listed in issueValue.
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"lazylinear/internal/api"
+)
+
+// maxSteps bounds the work of one view or action call, so a runaway script
+// cannot hang the UI
+const maxSteps = 10_000_000
+
+// View is a computed view: the issues its filter keeps, ordered by its key
+type View struct {
+	Name    string
+	filter  starlark.Callable
+	key     starlark.Callable
+	reverse bool
+}
+
+// Action is a custom action bound to a key on the issue list
+type Action struct {
+	Key         rune
+	Description string
+	fn          starlark.Callable
+}
+
+// Scripts are the views and actions defined by the loaded scripts
+type Scripts struct {
+	Views   []View
+	Actions []Action
+}
+
+// Dir is where scripts are loaded from, e.g. ~/.lazylinear/scripts
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lazylinear", "scripts"), nil
+}
+
+// Load runs every *.star file in dir in name order. A missing directory
+// loads nothing; scripts that fail are skipped and their errors returned
+// together with whatever the other scripts defined.
+func Load(dir string) (*Scripts, error) {
+	scripts := &Scripts{}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.star"))
+	if err != nil {
+		return scripts, err
+	}
+	sort.Strings(paths)
+	var errs []error
+	for _, path := range paths {
+		if err := scripts.load(path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+		}
+	}
+	return scripts, errors.Join(errs...)
+}
+
+// load runs one script, keeping its views and actions only if it succeeds
+func (s *Scripts) load(path string) error {
+	var views []View
+	var actions []Action
+	viewBuiltin := starlark.NewBuiltin("view", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var v View
+		var filter, key starlark.Value = starlark.None, starlark.None
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "name", &v.Name, "filter?", &filter, "key?", &key, "reverse?", &v.reverse); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(v.Name) == "" {
+			return nil, errors.New("empty name")
+		}
+		var err error
+		if v.filter, err = optionalCallable("filter", filter); err != nil {
+			return nil, err
+		}
+		if v.key, err = optionalCallable("key", key); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+		return starlark.None, nil
+	})
+	actionBuiltin := starlark.NewBuiltin("action", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		var a Action
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "key", &key, "description", &a.Description, "fn", &a.fn); err != nil {
+			return nil, err
+		}
+		r, size := utf8.DecodeRuneInString(key)
+		if size == 0 || size != len(key) || r == ' ' {
+			return nil, fmt.Errorf("key %q is not a single character", key)
+		}
+		a.Key = r
+		actions = append(actions, a)
+		return starlark.None, nil
+	})
+
+	thread := &starlark.Thread{Name: filepath.Base(path)}
+	thread.SetMaxExecutionSteps(maxSteps)
+	predeclared := starlark.StringDict{
+		"view":   viewBuiltin,
+		"action": actionBuiltin,
+		"struct": starlark.NewBuiltin("struct", starlarkstruct.Make),
+		"linear": linear,
+	}
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{Set: true, While: true, TopLevelControl: true}, thread, path, nil, predeclared)
+	if err != nil {
+		var evalErr *starlark.EvalError
+		if errors.As(err, &evalErr) {
+			return errors.New(evalErr.Backtrace())
+		}
+		return err
+	}
+	// Frozen values may be shared by concurrent calls
+	globals.Freeze()
+	s.Views = append(s.Views, views...)
+	s.Actions = append(s.Actions, actions...)
+	return nil
+}
+
+// optionalCallable checks that an optional argument is a function or None
+func optionalCallable(param string, v starlark.Value) (starlark.Callable, error) {
+	if v == starlark.None {
+		return nil, nil
+	}
+	c, ok := v.(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a function, got %s", param, v.Type())
+	}
+	return c, nil
+}
+
+// Apply returns the issues the view keeps, in its order
+func (v View) Apply(issues []api.Issue) ([]api.Issue, error) {
+	thread := &starlark.Thread{Name: "view " + v.Name}
+	thread.SetMaxExecutionSteps(maxSteps)
+	var kept []api.Issue
+	var keys []starlark.Value
+	for _, issue := range issues {
+		value := issueValue(issue)
+		if v.filter != nil {
+			ok, err := starlark.Call(thread, v.filter, starlark.Tuple{value}, nil)
+			if err != nil {
+				return nil, err
+			}
+			if !ok.Truth() {
+				continue
+			}
+		}
+		kept = append(kept, issue)
+		if v.key != nil {
+			key, err := starlark.Call(thread, v.key, starlark.Tuple{value}, nil)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+	}
+	if v.key == nil {
+		return kept, nil
+	}
+
+	order := make([]int, len(kept))
+	for i := range order {
+		order[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(order, func(i, j int) bool {
+		x, y := keys[order[i]], keys[order[j]]
+		if v.reverse {
+			x, y = y, x
+		}
+		less, err := starlark.Compare(syntax.LT, x, y)
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return less
+	})
+	if sortErr != nil {
+		return nil, fmt.Errorf("sorting view %s: %w", v.Name, sortErr)
+	}
+	sorted := make([]api.Issue, len(kept))
+	for i, pos := range order {
+		sorted[i] = kept[pos]
+	}
+	return sorted, nil
+}
+
+// Run calls the action on an issue, letting it use the client through the
+// linear module, and returns the text it returned, if any
+func (a Action) Run(ctx context.Context, client *api.Client, issue api.Issue) (string, error) {
+	thread := &starlark.Thread{Name: "action " + a.Description}
+	thread.SetMaxExecutionSteps(maxSteps)
+	thread.SetLocal(sessionKey, &session{ctx: ctx, client: client})
+	result, err := starlark.Call(thread, a.fn, starlark.Tuple{issueValue(issue)}, nil)
+	if err != nil {
+		var evalErr *starlark.EvalError
+		if errors.As(err, &evalErr) {
+			return "", errors.New(evalErr.Backtrace())
+		}
+		return "", err
+	}
+	switch result := result.(type) {
+	case starlark.NoneType:
+		return "", nil
+	case starlark.String:
+		return string(result), nil
+	default:
+		return result.String(), nil
+	}
+}
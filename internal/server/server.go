@@ -0,0 +1,424 @@
+// Package server answers JSON-RPC 2.0 requests about the loaded issues over
+// a local socket, so editors, status bars, and agents can share one cached
+// client. Requests and responses are JSON objects, one per line:
+//
+//	{"jsonrpc": "2.0", "id": 1, "method": "issues.mine"}
+//	{"jsonrpc": "2.0", "id": 1, "result": [{"identifier": "ENG-123", ...}]}
+//
+// Methods:
+//
+//	viewer                              the authenticated user
+//	issues.list    {mine, state, team, label, search}
+//	                                    cached issues passing every given filter
+//	issues.mine                         cached issues assigned to the viewer
+//	issues.get     {issue}              an issue by identifier or ID, in full
+//	issues.branch  {branch}             the cached issue of a git branch, or null
+//	issues.refresh                      reload the cache; returns the issue count
+//	issues.comment {issue, body}        comment on an issue
+//	issues.setState {issue, state}      move an issue to the named workflow state
+//	issues.assign  {issue, assignee}    assign to a user ID, "me", or "" to unassign
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/store"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+// maxRequestSize caps a single request line
+const maxRequestSize = 1 << 20
+
+// requestTimeout bounds the API calls made for one request
+const requestTimeout = 30 * time.Second
+
+// Server keeps the issues of every team loaded and answers requests about
+// them
+type Server struct {
+	client *api.Client
+	store  *store.Store
+	log    *slog.Logger
+
+	mu     sync.Mutex
+	viewer *api.Viewer
+}
+
+// New returns a server for client; logger may be nil
+func New(client *api.Client, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Server{client: client, store: store.New(client.CanonicalState), log: logger}
+}
+
+// Refresh reloads the viewer and the issues of every team
+func (s *Server) Refresh(ctx context.Context) error {
+	viewer, err := s.client.GetViewer(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching viewer: %w", err)
+	}
+	issues, err := s.client.GetAllIssues(ctx, api.IssueQuery{})
+	if err != nil {
+		return fmt.Errorf("loading issues: %w", err)
+	}
+	s.mu.Lock()
+	s.viewer = viewer
+	s.mu.Unlock()
+	s.store.SetViewer(viewer.ID)
+	s.store.SetIssues(issues)
+	return nil
+}
+
+// Serve accepts connections on l until it is closed, answering each
+// connection's requests in order
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// request is a JSON-RPC 2.0 request; notifications have no ID
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error member of a failed response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRequestSize)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req request
+		resp := response{JSONRPC: "2.0", ID: json.RawMessage("null")}
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			resp.Error = &rpcError{Code: codeParseError, Message: err.Error()}
+		} else if req.JSONRPC != "2.0" || req.Method == "" {
+			resp.Error = &rpcError{Code: codeInvalidRequest, Message: `expected "jsonrpc": "2.0" and a method`}
+		} else {
+			result, err := s.call(req.Method, req.Params)
+			s.log.Debug("request", "method", req.Method, "err", err)
+			if len(req.ID) == 0 {
+				// Notifications get no response
+				continue
+			}
+			resp.ID = req.ID
+			var rpcErr *rpcError
+			switch {
+			case errors.As(err, &rpcErr):
+				resp.Error = rpcErr
+			case err != nil:
+				resp.Error = &rpcError{Code: codeServerError, Message: err.Error()}
+			case result == nil:
+				resp.Result = struct{}{}
+			default:
+				resp.Result = result
+			}
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// listParams filters issues.list; empty fields match everything
+type listParams struct {
+	Mine   bool   `json:"mine"`
+	State  string `json:"state"`
+	Team   string `json:"team"`
+	Label  string `json:"label"`
+	Search string `json:"search"`
+}
+
+// issueParams names the issue an action applies to, by identifier or ID
+type issueParams struct {
+	Issue    string `json:"issue"`
+	Body     string `json:"body"`
+	State    string `json:"state"`
+	Assignee string `json:"assignee"`
+}
+
+// call runs a method and returns its result
+func (s *Server) call(method string, raw json.RawMessage) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	switch method {
+	case "viewer":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.viewer, nil
+	case "issues.list":
+		var p listParams
+		if err := decodeParams(raw, &p); err != nil {
+			return nil, err
+		}
+		return s.list(p), nil
+	case "issues.mine":
+		return s.list(listParams{Mine: true}), nil
+	case "issues.branch":
+		var p struct {
+			Branch string `json:"branch"`
+		}
+		if err := decodeParams(raw, &p); err != nil {
+			return nil, err
+		}
+		if issue, ok := IssueForBranch(s.store.All(), p.Branch); ok {
+			return issue, nil
+		}
+		return json.RawMessage("null"), nil
+	case "issues.refresh":
+		if err := s.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		return len(s.store.All()), nil
+	}
+
+	switch method {
+	case "issues.get", "issues.comment", "issues.setState", "issues.assign":
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "unknown method " + method}
+	}
+	var p issueParams
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.Issue == "" {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "missing issue"}
+	}
+	switch method {
+	case "issues.get":
+		return s.client.GetIssue(ctx, s.issueID(p.Issue))
+	case "issues.comment":
+		if strings.TrimSpace(p.Body) == "" {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "missing body"}
+		}
+		return nil, s.client.AddComment(ctx, s.issueID(p.Issue), p.Body)
+	case "issues.setState":
+		return s.setState(ctx, p.Issue, p.State)
+	case "issues.assign":
+		assignee := p.Assignee
+		if assignee == "me" {
+			s.mu.Lock()
+			if s.viewer != nil {
+				assignee = s.viewer.ID
+			}
+			s.mu.Unlock()
+		}
+		id := s.issueID(p.Issue)
+		if err := s.client.AssignIssue(ctx, id, assignee); err != nil {
+			return nil, err
+		}
+		return s.reload(ctx, id)
+	}
+	return nil, nil
+}
+
+// decodeParams unmarshals optional params into v
+func decodeParams(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// list returns the cached issues passing p
+func (s *Server) list(p listParams) []api.Issue {
+	s.mu.Lock()
+	viewerID := ""
+	if s.viewer != nil {
+		viewerID = s.viewer.ID
+	}
+	s.mu.Unlock()
+
+	matched := []api.Issue{}
+	for _, issue := range s.store.All() {
+		if p.Mine && issue.Assignee.ID != viewerID {
+			continue
+		}
+		if p.State != "" && !strings.EqualFold(s.client.CanonicalState(issue.State.Name), p.State) {
+			continue
+		}
+		if p.Team != "" && !strings.EqualFold(issue.Team.Key, p.Team) && issue.Team.ID != p.Team {
+			continue
+		}
+		if p.Label != "" && !hasLabel(issue, p.Label) {
+			continue
+		}
+		if p.Search != "" && !strings.Contains(strings.ToLower(issue.Title), strings.ToLower(p.Search)) {
+			continue
+		}
+		matched = append(matched, issue)
+	}
+	return matched
+}
+
+// hasLabel reports whether the issue carries the named label, ignoring case
+func hasLabel(issue api.Issue, name string) bool {
+	for _, label := range issue.Labels.Nodes {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// issueID resolves a cached identifier to its ID; anything else is passed
+// through for the API to resolve
+func (s *Server) issueID(ref string) string {
+	for _, issue := range s.store.All() {
+		if strings.EqualFold(issue.Identifier, ref) {
+			return issue.ID
+		}
+	}
+	return ref
+}
+
+// setState moves an issue to its team's workflow state named state
+func (s *Server) setState(ctx context.Context, ref, state string) (*api.Issue, error) {
+	if state == "" {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "missing state"}
+	}
+	issue, err := s.client.GetIssue(ctx, s.issueID(ref))
+	if err != nil {
+		return nil, err
+	}
+	states, err := s.client.GetTeamStates(ctx, issue.Team.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range states {
+		if strings.EqualFold(ws.Name, state) {
+			if err := s.client.SetIssueState(ctx, issue.ID, ws.ID); err != nil {
+				return nil, err
+			}
+			return s.reload(ctx, issue.ID)
+		}
+	}
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("no workflow state named %q", state)}
+}
+
+// reload fetches a changed issue and updates the cache with it
+func (s *Server) reload(ctx context.Context, id string) (*api.Issue, error) {
+	issue, err := s.client.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.store.UpsertIssue(*issue)
+	return issue, nil
+}
+
+// branchReference finds an issue identifier inside a branch name, e.g. ENG-123
+// in ben/eng-123-fix-login
+var branchReference = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9]*-[0-9]+\b`)
+
+// BranchIdentifier returns the issue identifier mentioned in a git branch
+// name, upper-cased, or "" if there is none
+func BranchIdentifier(branch string) string {
+	return strings.ToUpper(branchReference.FindString(branch))
+}
+
+// IssueForBranch returns the issue whose git branch name is branch, or else
+// the issue the branch name mentions
+func IssueForBranch(issues []api.Issue, branch string) (api.Issue, bool) {
+	if branch == "" {
+		return api.Issue{}, false
+	}
+	for _, issue := range issues {
+		if strings.EqualFold(issue.BranchName, branch) {
+			return issue, true
+		}
+	}
+	identifier := BranchIdentifier(branch)
+	for _, issue := range issues {
+		if identifier != "" && strings.EqualFold(issue.Identifier, identifier) {
+			return issue, true
+		}
+	}
+	return api.Issue{}, false
+}
+
+// Call sends one request to the server listening on socket and decodes its
+// result into result, giving up after timeout
+func Call(socket, method string, params, result interface{}, timeout time.Duration) error {
+	conn, err := net.DialTimeout("unix", socket, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req := request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: raw}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return json.Unmarshal(resp.Result, result)
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"lazylinear/internal/api"
+)
+
+// fakeLinear answers the queries the server makes with a fixed workspace
+func fakeLinear(t *testing.T) *httptest.Server {
+	issue := func(n int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":         fmt.Sprintf("id-%d", n),
+			"identifier": fmt.Sprintf("ENG-%d", n),
+			"title":      fmt.Sprintf("Issue %d", n),
+			"branchName": fmt.Sprintf("me/eng-%d-issue", n),
+			"state":      map[string]string{"name": "Todo"},
+			"team":       map[string]string{"id": "team", "key": "ENG"},
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		var data interface{}
+		switch {
+		case strings.Contains(body.Query, "issueUpdate"):
+			data = map[string]interface{}{"issueUpdate": map[string]bool{"success": true}}
+		case strings.Contains(body.Query, "issue(id:"):
+			data = map[string]interface{}{"issue": issue(1)}
+		case strings.Contains(body.Query, "issues("):
+			var nodes []interface{}
+			for n := 1; n <= 20; n++ {
+				nodes = append(nodes, issue(n))
+			}
+			data = map[string]interface{}{"issues": map[string]interface{}{"nodes": nodes, "pageInfo": map[string]interface{}{"hasNextPage": false}}}
+		default:
+			data = map[string]interface{}{"viewer": map[string]string{"id": "me", "name": "Me"}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestConcurrentClients(t *testing.T) {
+	client := api.NewClient("key")
+	client.SetEndpoint(fakeLinear(t).URL)
+	s := New(client, nil)
+	if err := s.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	socket := filepath.Join(t.TempDir(), "test.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve(listener)
+	defer listener.Close()
+
+	calls := []struct {
+		method string
+		params interface{}
+	}{
+		{"issues.list", map[string]interface{}{"search": "issue"}},
+		{"issues.mine", nil},
+		{"issues.branch", map[string]string{"branch": "me/eng-3-issue"}},
+		{"issues.assign", map[string]string{"issue": "ENG-1", "assignee": "me"}},
+		{"issues.refresh", nil},
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*len(calls)*40)
+	for c := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 40 {
+				call := calls[(c+i)%len(calls)]
+				var result json.RawMessage
+				if err := Call(socket, call.method, call.params, &result, 5*time.Second); err != nil {
+					errs <- fmt.Errorf("%s: %w", call.method, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestIssueForBranch(t *testing.T) {
+	var a, b api.Issue
+	a.Identifier, a.BranchName = "ENG-1", "me/eng-1-login"
+	b.Identifier, b.BranchName = "ENG-2", "custom-branch"
+	issues := []api.Issue{a, b}
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"me/eng-1-login", "ENG-1"},
+		{"custom-branch", "ENG-2"},
+		{"other/ENG-2-retry", "ENG-2"},
+		{"main", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, ok := IssueForBranch(issues, tt.branch)
+			if ok != (tt.want != "") || got.Identifier != tt.want {
+				t.Errorf("IssueForBranch(%q) = %q, %v; want %q", tt.branch, got.Identifier, ok, tt.want)
+			}
+		})
+	}
+}
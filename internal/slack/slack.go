@@ -0,0 +1,54 @@
+// Package slack posts messages to Slack through incoming webhooks.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"lazylinear/internal/api"
+)
+
+// escaper escapes the characters Slack's mrkdwn gives meaning to
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// IssueMessage formats an issue as a Slack message: the note, then the
+// identifier and title linked to the issue
+func IssueMessage(issue api.Issue, note string) string {
+	link := fmt.Sprintf("<%s|%s %s>", issue.URL, issue.Identifier, escaper.Replace(issue.Title))
+	if issue.URL == "" {
+		link = issue.Identifier + " " + escaper.Replace(issue.Title)
+	}
+	if note == "" {
+		return link
+	}
+	return escaper.Replace(note) + "\n" + link
+}
+
+// Post sends text to an incoming webhook
+func Post(ctx context.Context, webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// Slack explains rejected posts in a short plain-text body
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("slack returned %s: %s", resp.Status, strings.TrimSpace(string(reason)))
+	}
+	return nil
+}
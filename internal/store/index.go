@@ -0,0 +1,123 @@
+package store
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"lazylinear/internal/api"
+)
+
+// searchIndex maps lowercase tokens of each issue's identifier, title, and
+// labels to the issue's position, so a search only visits the issues it
+// matches
+type searchIndex struct {
+	// tokens lists each distinct token once, numbered by position
+	tokens []string
+	// postings lists the positions of the issues with each token, by token
+	postings [][]int
+	// suffixes lists every suffix of every token in sorted order, so the
+	// tokens containing a word are those with a suffix starting with it,
+	// found by binary search. It is sorted on the first search, sparing
+	// upserts the cost while nobody searches.
+	sortOnce sync.Once
+	suffixes []suffix
+}
+
+// suffix is the tail of the token numbered token
+type suffix struct {
+	text  string
+	token int
+}
+
+// buildIndex indexes issues by position
+func buildIndex(issues []api.Issue) *searchIndex {
+	x := &searchIndex{}
+	ids := make(map[string]int)
+	for i, issue := range issues {
+		text := []string{issue.Identifier, issue.Title}
+		for _, label := range issue.Labels.Nodes {
+			text = append(text, label.Name)
+		}
+		seen := make(map[string]bool)
+		for _, token := range tokenize(strings.Join(text, " ")) {
+			if seen[token] {
+				continue
+			}
+			seen[token] = true
+			id, ok := ids[token]
+			if !ok {
+				id = len(x.tokens)
+				ids[token] = id
+				x.tokens = append(x.tokens, token)
+				x.postings = append(x.postings, nil)
+			}
+			x.postings[id] = append(x.postings[id], i)
+		}
+	}
+	return x
+}
+
+// sortSuffixes fills in the sorted suffixes of every token
+func (x *searchIndex) sortSuffixes() {
+	for id, token := range x.tokens {
+		for start := range token {
+			x.suffixes = append(x.suffixes, suffix{token[start:], id})
+		}
+	}
+	slices.SortFunc(x.suffixes, func(a, b suffix) int { return strings.Compare(a.text, b.text) })
+}
+
+// tokenize splits s into lowercase words, adding the alphanumeric parts of
+// words such as "ENG-123" or "api/client" so either half finds them
+func tokenize(s string) []string {
+	var tokens []string
+	for _, word := range strings.Fields(strings.ToLower(s)) {
+		word = strings.TrimFunc(word, isSeparator)
+		if word == "" {
+			continue
+		}
+		tokens = append(tokens, word)
+		if parts := strings.FieldsFunc(word, isSeparator); len(parts) > 1 {
+			tokens = append(tokens, parts...)
+		}
+	}
+	return tokens
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// search returns the positions, in order, of the issues with a token
+// containing every word of query, so "flow" still finds "workflow"
+func (x *searchIndex) search(query string) []int {
+	x.sortOnce.Do(x.sortSuffixes)
+	var matched map[int]bool
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		hits := make(map[int]bool)
+		first := sort.Search(len(x.suffixes), func(i int) bool { return x.suffixes[i].text >= word })
+		for _, suffix := range x.suffixes[first:] {
+			if !strings.HasPrefix(suffix.text, word) {
+				break
+			}
+			for _, pos := range x.postings[suffix.token] {
+				if matched == nil || matched[pos] {
+					hits[pos] = true
+				}
+			}
+		}
+		matched = hits
+		if len(matched) == 0 {
+			return nil
+		}
+	}
+	positions := make([]int, 0, len(matched))
+	for pos := range matched {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	return positions
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"lazylinear/internal/api"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Fix login", []string{"fix", "login"}},
+		{"ENG-123", []string{"eng-123", "eng", "123"}},
+		{"api/client.go", []string{"api/client.go", "api", "client", "go"}},
+		{"(draft) Café menu!", []string{"draft", "café", "menu"}},
+		{"  --  ", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	issues := []api.Issue{
+		issue("ENG-12", "Fix login workflow", "Todo", "", "", "Bug"),
+		issue("ENG-123", "Flow chart for billing", "Todo", "", ""),
+		issue("OPS-7", "Rotate API keys", "Todo", "", "", "Security"),
+		issue("OPS-8", "Café menu", "Todo", "", ""),
+	}
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"flow", []int{0, 1}},
+		{"FLOW", []int{0, 1}},
+		{"workflow", []int{0}},
+		{"eng-12", []int{0, 1}},
+		{"eng-123", []int{1}},
+		{"12", []int{0, 1}},
+		{"ops", []int{2, 3}},
+		{"security", []int{2}},
+		{"curi", []int{2}},
+		{"flow bill", []int{1}},
+		{"flow keys", nil},
+		{"afé", []int{3}},
+		{"zzz", nil},
+	}
+	x := buildIndex(issues)
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := x.search(tt.query)
+			if len(got) == 0 {
+				got = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
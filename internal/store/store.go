@@ -0,0 +1,322 @@
+// Package store holds the state behind the issue list: the teams, the
+// current team's loaded issues, and the active filters. State changes only
+// through the action methods, each of which notifies subscribers so views
+// can re-render from the new state.
+package store
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"lazylinear/internal/api"
+)
+
+// AllView is the view name that lists issues in every state
+const AllView = "All"
+
+// AllTeams is the team index that lists the issues of every team
+const AllTeams = -1
+
+// Unassigned is the Filter.Assignee value that keeps only unassigned issues
+const Unassigned = "unassigned"
+
+// Computed picks and orders the issues of a computed view, such as one
+// defined by a user script
+type Computed func(issues []api.Issue) []api.Issue
+
+// Filter selects which loaded issues are listed
+type Filter struct {
+	// View is a canonical state name, AllView, or the name of a computed view
+	View string
+	// AssignedToMe keeps only issues assigned to the viewer
+	AssignedToMe bool
+	// StartableOnly keeps only issues that can be picked up now
+	StartableOnly bool
+	// DueThisWeek and Overdue keep only unresolved issues due within the
+	// next 7 days or already past due; with both set, either qualifies
+	DueThisWeek bool
+	Overdue     bool
+	// Assignee keeps only issues assigned to the user with this ID, or
+	// Unassigned
+	Assignee string
+	// Label keeps only issues carrying the label with this name, ignoring case
+	Label string
+	// Stalest lists the least recently updated issues first
+	Stalest bool
+	// Search keeps only issues with a word containing each word of this
+	// text in their identifier, title, or labels
+	Search string
+}
+
+// Store is safe for concurrent use. Slices it returns are never changed
+// afterwards, so callers may read them without holding anything. Subscribers
+// run on the goroutine that performed the action, after the store's lock is
+// released.
+type Store struct {
+	mu        sync.RWMutex
+	teams     []api.Team
+	team      int
+	issues    []api.Issue
+	index     *searchIndex
+	visible   []api.Issue
+	filter    Filter
+	viewerID  string
+	canonical func(string) string
+	computed  map[string]Computed
+
+	subscribers []func()
+}
+
+// New returns an empty store. canonical maps workflow state names onto the
+// canonical view names, e.g. to honor configured state aliases.
+func New(canonical func(string) string) *Store {
+	if canonical == nil {
+		canonical = func(name string) string { return name }
+	}
+	return &Store{filter: Filter{View: AllView}, index: buildIndex(nil), canonical: canonical}
+}
+
+// Subscribe registers fn to be called after every action
+func (s *Store) Subscribe(fn func()) {
+	s.mu.Lock()
+	s.subscribers = append(s.subscribers, fn)
+	s.mu.Unlock()
+}
+
+// update applies change under the lock, refilters, and notifies subscribers
+func (s *Store) update(change func()) {
+	s.mu.Lock()
+	change()
+	s.visible = s.filterLocked()
+	subscribers := append([]func(){}, s.subscribers...)
+	s.mu.Unlock()
+	for _, fn := range subscribers {
+		fn()
+	}
+}
+
+// SetTeams replaces the team list and selects the team at index current
+func (s *Store) SetTeams(teams []api.Team, current int) {
+	s.update(func() {
+		s.teams = teams
+		s.team = current
+	})
+}
+
+// SelectTeam makes the team at index i, or AllTeams, current; it reports
+// false when i is out of range. The caller loads the team's issues.
+func (s *Store) SelectTeam(i int) bool {
+	s.mu.RLock()
+	ok := i >= AllTeams && i < len(s.teams)
+	s.mu.RUnlock()
+	if ok {
+		s.update(func() { s.team = i })
+	}
+	return ok
+}
+
+// SetViewer records who "assigned to me" refers to
+func (s *Store) SetViewer(id string) {
+	s.update(func() { s.viewerID = id })
+}
+
+// SetIssues replaces the loaded issues
+func (s *Store) SetIssues(issues []api.Issue) {
+	s.update(func() {
+		s.issues = issues
+		s.index = buildIndex(issues)
+	})
+}
+
+// UpsertIssue replaces the loaded issue with the same ID, or adds it. The
+// issues are copied rather than changed in place, since slices returned by
+// All and Issues may still be read by other goroutines.
+func (s *Store) UpsertIssue(issue api.Issue) {
+	s.update(func() {
+		issues := make([]api.Issue, len(s.issues), len(s.issues)+1)
+		copy(issues, s.issues)
+		replaced := false
+		for i := range issues {
+			if issues[i].ID == issue.ID {
+				issues[i] = issue
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			issues = append(issues, issue)
+		}
+		s.issues = issues
+		s.index = buildIndex(issues)
+	})
+}
+
+// SetComputed replaces the computed views, keyed by view name
+func (s *Store) SetComputed(views map[string]Computed) {
+	s.update(func() { s.computed = views })
+}
+
+// SetFilter replaces the active filter
+func (s *Store) SetFilter(f Filter) {
+	s.update(func() { s.filter = f })
+}
+
+// UpdateFilter changes the active filter in place
+func (s *Store) UpdateFilter(change func(f *Filter)) {
+	s.update(func() { change(&s.filter) })
+}
+
+// Teams returns the team list
+func (s *Store) Teams() []api.Team {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.teams
+}
+
+// TeamIndex returns the index of the current team
+func (s *Store) TeamIndex() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.team
+}
+
+// CurrentTeam returns the current team, if there is one; there is none
+// while AllTeams is selected
+func (s *Store) CurrentTeam() (api.Team, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.team < 0 || s.team >= len(s.teams) {
+		return api.Team{}, false
+	}
+	return s.teams[s.team], true
+}
+
+// All returns every loaded issue of the current team, or of every team
+// while AllTeams is selected
+func (s *Store) All() []api.Issue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.issues
+}
+
+// Issues returns the loaded issues that pass the filter
+func (s *Store) Issues() []api.Issue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.visible
+}
+
+// Filter returns the active filter
+func (s *Store) Filter() Filter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.filter
+}
+
+// StateName returns the canonical state of an issue
+func (s *Store) StateName(issue api.Issue) string {
+	return s.canonical(issue.State.Name)
+}
+
+// Startable reports whether an issue is actionable now: in Todo or Backlog,
+// assigned to the viewer or unassigned, and not blocked by unresolved issues
+func (s *Store) Startable(issue api.Issue) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.startableLocked(issue)
+}
+
+func (s *Store) startableLocked(issue api.Issue) bool {
+	state := s.StateName(issue)
+	if state != "Todo" && state != "Backlog" {
+		return false
+	}
+	if issue.Assignee.ID != "" && issue.Assignee.ID != s.viewerID {
+		return false
+	}
+	return len(issue.Blockers()) == 0
+}
+
+// filterLocked returns the issues passing the filter; s.mu must be held. A
+// search visits only the issues the index matches.
+func (s *Store) filterLocked() []api.Issue {
+	candidates := s.issues
+	if strings.TrimSpace(s.filter.Search) != "" {
+		candidates = nil
+		for _, pos := range s.index.search(s.filter.Search) {
+			candidates = append(candidates, s.issues[pos])
+		}
+	}
+
+	computed, isComputed := s.computed[s.filter.View]
+	now := time.Now()
+	var filtered []api.Issue
+	for _, issue := range candidates {
+		if s.filter.AssignedToMe && issue.Assignee.ID != s.viewerID {
+			continue
+		}
+		if !isComputed && s.filter.View != AllView && s.filter.View != "" && s.StateName(issue) != s.filter.View {
+			continue
+		}
+		if s.filter.StartableOnly && !s.startableLocked(issue) {
+			continue
+		}
+		if (s.filter.DueThisWeek || s.filter.Overdue) && !s.dueMatches(issue, now) {
+			continue
+		}
+		if s.filter.Assignee != "" && !assigneeMatches(issue, s.filter.Assignee) {
+			continue
+		}
+		if s.filter.Label != "" && !hasLabel(issue, s.filter.Label) {
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	if isComputed {
+		filtered = computed(filtered)
+	}
+	if s.filter.Stalest {
+		// Timestamps share one RFC 3339 layout, so they sort as strings
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].UpdatedAt < filtered[j].UpdatedAt
+		})
+	}
+	return filtered
+}
+
+// dueMatches reports whether an unresolved issue passes the due date filters
+func (s *Store) dueMatches(issue api.Issue, now time.Time) bool {
+	if issue.DueDate == "" || issue.CompletedAt != "" || issue.CanceledAt != "" {
+		return false
+	}
+	due, err := time.ParseInLocation("2006-01-02", issue.DueDate, time.Local)
+	if err != nil {
+		return false
+	}
+	// Issues are due by the end of their due date
+	end := due.AddDate(0, 0, 1)
+	if now.After(end) {
+		return s.filter.Overdue
+	}
+	return s.filter.DueThisWeek && end.Sub(now) <= 7*24*time.Hour
+}
+
+// hasLabel reports whether the issue carries the named label, ignoring case
+func hasLabel(issue api.Issue, name string) bool {
+	for _, label := range issue.Labels.Nodes {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// assigneeMatches reports whether the issue passes the assignee filter
+func assigneeMatches(issue api.Issue, assignee string) bool {
+	if assignee == Unassigned {
+		return issue.Assignee.ID == ""
+	}
+	return issue.Assignee.ID == assignee
+}
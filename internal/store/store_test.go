@@ -0,0 +1,167 @@
+package store
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"lazylinear/internal/api"
+)
+
+// issue builds an issue in a state, assigned to assignee, with labels
+func issue(id, title, state, assignee, updated string, labels ...string) api.Issue {
+	var i api.Issue
+	i.ID, i.Identifier, i.Title, i.UpdatedAt = id, id, title, updated
+	i.State.Name = state
+	i.Assignee.ID = assignee
+	for _, label := range labels {
+		i.Labels.Nodes = append(i.Labels.Nodes, struct {
+			Name string `json:"name"`
+		}{label})
+	}
+	return i
+}
+
+// ids returns the IDs of issues, in order
+func ids(issues []api.Issue) []string {
+	var out []string
+	for _, i := range issues {
+		out = append(out, i.ID)
+	}
+	return out
+}
+
+var testIssues = []api.Issue{
+	issue("ENG-1", "Fix login workflow", "Todo", "me", "2026-01-03T00:00:00Z", "Bug"),
+	issue("ENG-2", "Add dark mode", "In Progress", "", "2026-01-01T00:00:00Z", "Feature"),
+	issue("ENG-3", "Rotate API keys", "Waiting", "them", "2026-01-02T00:00:00Z"),
+}
+
+func TestFilter(t *testing.T) {
+	aliases := map[string]string{"Waiting": "Blocked"}
+	canonical := func(name string) string {
+		if c, ok := aliases[name]; ok {
+			return c
+		}
+		return name
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all", Filter{View: AllView}, []string{"ENG-1", "ENG-2", "ENG-3"}},
+		{"state view", Filter{View: "In Progress"}, []string{"ENG-2"}},
+		{"aliased state view", Filter{View: "Blocked"}, []string{"ENG-3"}},
+		{"assigned to me", Filter{View: AllView, AssignedToMe: true}, []string{"ENG-1"}},
+		{"unassigned", Filter{View: AllView, Assignee: Unassigned}, []string{"ENG-2"}},
+		{"assignee", Filter{View: AllView, Assignee: "them"}, []string{"ENG-3"}},
+		{"label ignores case", Filter{View: AllView, Label: "bug"}, []string{"ENG-1"}},
+		{"startable", Filter{View: AllView, StartableOnly: true}, []string{"ENG-1"}},
+		{"search", Filter{View: AllView, Search: "flow"}, []string{"ENG-1"}},
+		{"search and state", Filter{View: "Todo", Search: "mode"}, nil},
+		{"stalest", Filter{View: AllView, Stalest: true}, []string{"ENG-2", "ENG-3", "ENG-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(canonical)
+			s.SetViewer("me")
+			s.SetIssues(testIssues)
+			s.SetFilter(tt.filter)
+			if got := ids(s.Issues()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Issues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertIssue(t *testing.T) {
+	tests := []struct {
+		name      string
+		upsert    api.Issue
+		wantIDs   []string
+		wantTitle string
+	}{
+		{"replaces", issue("ENG-2", "Add light mode", "Todo", "", ""), []string{"ENG-1", "ENG-2", "ENG-3"}, "Add light mode"},
+		{"appends", issue("ENG-4", "New issue", "Todo", "", ""), []string{"ENG-1", "ENG-2", "ENG-3", "ENG-4"}, "New issue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(nil)
+			s.SetIssues(append([]api.Issue{}, testIssues...))
+			before := s.All()
+			s.UpsertIssue(tt.upsert)
+
+			all := s.All()
+			if got := ids(all); !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("All() = %v, want %v", got, tt.wantIDs)
+			}
+			for _, i := range all {
+				if i.ID == tt.upsert.ID && i.Title != tt.wantTitle {
+					t.Errorf("title = %q, want %q", i.Title, tt.wantTitle)
+				}
+			}
+			// Earlier slices are left as they were
+			if got := ids(before); !reflect.DeepEqual(got, ids(testIssues)) || before[1].Title != testIssues[1].Title {
+				t.Errorf("earlier All() changed to %v", before)
+			}
+			// The search index follows the new issue
+			s.SetFilter(Filter{View: AllView, Search: tt.wantTitle})
+			if got := ids(s.Issues()); !reflect.DeepEqual(got, []string{tt.upsert.ID}) {
+				t.Errorf("search for %q = %v", tt.wantTitle, got)
+			}
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(s *Store)
+	}{
+		{"SetTeams", func(s *Store) { s.SetTeams([]api.Team{{ID: "T"}}, 0) }},
+		{"SelectTeam", func(s *Store) { s.SelectTeam(AllTeams) }},
+		{"SetViewer", func(s *Store) { s.SetViewer("me") }},
+		{"SetIssues", func(s *Store) { s.SetIssues(testIssues) }},
+		{"UpsertIssue", func(s *Store) { s.UpsertIssue(testIssues[0]) }},
+		{"SetFilter", func(s *Store) { s.SetFilter(Filter{View: "Todo"}) }},
+		{"UpdateFilter", func(s *Store) { s.UpdateFilter(func(f *Filter) { f.Label = "Bug" }) }},
+		{"SetComputed", func(s *Store) { s.SetComputed(nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(nil)
+			calls := 0
+			s.Subscribe(func() {
+				// Subscribers run after the lock is released
+				s.Issues()
+				calls++
+			})
+			tt.action(s)
+			if calls != 1 {
+				t.Errorf("subscriber called %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestConcurrentReadsAndUpserts(t *testing.T) {
+	s := New(nil)
+	s.SetIssues(append([]api.Issue{}, testIssues...))
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				for _, i := range s.All() {
+					_ = i.Title
+				}
+			}
+		}()
+	}
+	for n := range 200 {
+		s.UpsertIssue(issue("ENG-1", "Title "+string(rune('a'+n%26)), "Todo", "", ""))
+	}
+	wg.Wait()
+}
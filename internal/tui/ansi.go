@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ansiState tracks an in-progress escape sequence and the SGR styles in effect
+type ansiState struct {
+	inEscape bool
+	inCSI    bool
+	params   []byte
+
+	fg, bg tcell.Color
+	fgSet  bool
+	bgSet  bool
+	attrs  tcell.AttrMask
+}
+
+// consume feeds r to the escape parser and reports whether it was part of an
+// escape sequence rather than printable text
+func (a *ansiState) consume(r rune) bool {
+	switch {
+	case r == '\033':
+		a.inEscape = true
+		a.inCSI = false
+		a.params = a.params[:0]
+		return true
+	case a.inEscape && !a.inCSI:
+		if r == '[' {
+			a.inCSI = true
+			return true
+		}
+		// Unsupported escape; drop it
+		a.inEscape = false
+		return true
+	case a.inCSI:
+		if (r >= '0' && r <= '9') || r == ';' {
+			a.params = append(a.params, byte(r))
+			return true
+		}
+		if r == 'm' {
+			a.applySGR(string(a.params))
+		}
+		// Other final bytes (cursor movement and the like) are ignored
+		a.inEscape = false
+		a.inCSI = false
+		return true
+	}
+	return false
+}
+
+// applySGR applies a Select Graphic Rendition parameter list. Unknown codes
+// are ignored.
+func (a *ansiState) applySGR(params string) {
+	if params == "" {
+		params = "0"
+	}
+	fields := strings.Split(params, ";")
+	codes := make([]int, len(fields))
+	for i, f := range fields {
+		codes[i], _ = strconv.Atoi(f)
+	}
+
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			a.fgSet, a.bgSet, a.attrs = false, false, 0
+		case code == 1:
+			a.attrs |= tcell.AttrBold
+		case code == 2:
+			a.attrs |= tcell.AttrDim
+		case code == 3:
+			a.attrs |= tcell.AttrItalic
+		case code == 4:
+			a.attrs |= tcell.AttrUnderline
+		case code == 7:
+			a.attrs |= tcell.AttrReverse
+		case code == 9:
+			a.attrs |= tcell.AttrStrikeThrough
+		case code == 22:
+			a.attrs &^= tcell.AttrBold | tcell.AttrDim
+		case code == 23:
+			a.attrs &^= tcell.AttrItalic
+		case code == 24:
+			a.attrs &^= tcell.AttrUnderline
+		case code == 27:
+			a.attrs &^= tcell.AttrReverse
+		case code == 29:
+			a.attrs &^= tcell.AttrStrikeThrough
+		case code >= 30 && code <= 37:
+			a.fg, a.fgSet = tcell.PaletteColor(code-30), true
+		case code >= 90 && code <= 97:
+			a.fg, a.fgSet = tcell.PaletteColor(code-90+8), true
+		case code == 39:
+			a.fgSet = false
+		case code >= 40 && code <= 47:
+			a.bg, a.bgSet = tcell.PaletteColor(code-40), true
+		case code >= 100 && code <= 107:
+			a.bg, a.bgSet = tcell.PaletteColor(code-100+8), true
+		case code == 49:
+			a.bgSet = false
+		case code == 38 || code == 48:
+			c, n := extendedColor(codes[i+1:])
+			i += n
+			if n == 0 {
+				continue
+			}
+			if code == 38 {
+				a.fg, a.fgSet = c, true
+			} else {
+				a.bg, a.bgSet = c, true
+			}
+		}
+	}
+}
+
+// extendedColor parses the arguments of a 38 or 48 code, either 5;n for the
+// 256-color palette or 2;r;g;b for true color. It returns the color and the
+// number of parameters consumed.
+func extendedColor(args []int) (tcell.Color, int) {
+	if len(args) >= 2 && args[0] == 5 {
+		return tcell.PaletteColor(args[1]), 2
+	}
+	if len(args) >= 4 && args[0] == 2 {
+		return tcell.NewRGBColor(int32(args[1]), int32(args[2]), int32(args[3])), 4
+	}
+	return tcell.ColorDefault, 0
+}
+
+// style returns the style for text written in the current SGR state, falling
+// back to the view's default colors
+func (a *ansiState) style(fg, bg Attribute) tcell.Style {
+	st := style(fg, bg)
+	if a.fgSet {
+		st = st.Foreground(a.fg)
+	}
+	if a.bgSet {
+		st = st.Background(a.bg)
+	}
+	if a.attrs != 0 {
+		_, _, attrs := st.Decompose()
+		st = st.Attributes(attrs | a.attrs)
+	}
+	return st
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Attribute is a color and text-style combination for view and frame cells
+type Attribute uint16
+
+// Colors in the terminal's basic 8-color palette
+const (
+	ColorDefault Attribute = iota
+	ColorBlack
+	ColorRed
+	ColorGreen
+	ColorYellow
+	ColorBlue
+	ColorMagenta
+	ColorCyan
+	ColorWhite
+)
+
+// Text styles, combinable with a color
+const (
+	AttrBold Attribute = 1 << (iota + 9)
+	AttrUnderline
+	AttrReverse
+)
+
+// color returns the tcell color of the attribute
+func (a Attribute) color() tcell.Color {
+	c := a & 0xFF
+	if c == ColorDefault {
+		return tcell.ColorDefault
+	}
+	return tcell.PaletteColor(int(c) - 1)
+}
+
+// attrMask returns the tcell text styles of the attribute
+func (a Attribute) attrMask() tcell.AttrMask {
+	var mask tcell.AttrMask
+	if a&AttrBold != 0 {
+		mask |= tcell.AttrBold
+	}
+	if a&AttrUnderline != 0 {
+		mask |= tcell.AttrUnderline
+	}
+	if a&AttrReverse != 0 {
+		mask |= tcell.AttrReverse
+	}
+	return mask
+}
+
+// style builds a tcell style from foreground and background attributes
+func style(fg, bg Attribute) tcell.Style {
+	return tcell.StyleDefault.Foreground(fg.color()).Background(bg.color()).Attributes(fg.attrMask() | bg.attrMask())
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"github.com/mattn/go-runewidth"
+)
+
+// Editor handles key presses in an editable view that have no keybinding
+type Editor interface {
+	Edit(v *View, key Key, ch rune, mod Modifier)
+}
+
+// EditorFunc adapts a function to the Editor interface
+type EditorFunc func(v *View, key Key, ch rune, mod Modifier)
+
+// Edit calls f(v, key, ch, mod)
+func (f EditorFunc) Edit(v *View, key Key, ch rune, mod Modifier) {
+	f(v, key, ch, mod)
+}
+
+// DefaultEditor provides basic text editing: typing, deletion, newlines, and
+// cursor movement
+var DefaultEditor Editor = EditorFunc(simpleEditor)
+
+func simpleEditor(v *View, key Key, ch rune, mod Modifier) {
+	switch {
+	case ch != 0 && mod == ModNone:
+		v.EditWrite(ch)
+	case key == KeySpace:
+		v.EditWrite(' ')
+	case key == KeyBackspace || key == KeyBackspace2:
+		v.EditDelete(true)
+	case key == KeyDelete:
+		v.EditDelete(false)
+	case key == KeyEnter:
+		v.EditNewLine()
+	case key == KeyArrowLeft:
+		v.MoveCursor(-1, 0)
+	case key == KeyArrowRight:
+		v.MoveCursor(1, 0)
+	case key == KeyArrowUp:
+		v.MoveCursor(0, -1)
+	case key == KeyArrowDown:
+		v.MoveCursor(0, 1)
+	case key == KeyHome || key == KeyCtrlA:
+		v.cx = 0
+	case key == KeyEnd || key == KeyCtrlE:
+		v.cx = len(v.line(v.cy))
+	}
+}
+
+// line returns buffer line y, or nil if it does not exist
+func (v *View) line(y int) []cell {
+	if y < 0 || y >= len(v.lines) {
+		return nil
+	}
+	return v.lines[y]
+}
+
+// ensureLine grows the buffer so line y exists
+func (v *View) ensureLine(y int) {
+	for len(v.lines) <= y {
+		v.lines = append(v.lines, nil)
+	}
+}
+
+// EditWrite inserts ch at the cursor and advances it
+func (v *View) EditWrite(ch rune) {
+	v.ensureLine(v.cy)
+	line := v.lines[v.cy]
+	if v.cx > len(line) {
+		v.cx = len(line)
+	}
+	c := cell{ch: ch, width: 1, style: style(v.FgColor, v.BgColor)}
+	if w := runewidth.RuneWidth(ch); w > 0 {
+		c.width = w
+	}
+	line = append(line, cell{})
+	copy(line[v.cx+1:], line[v.cx:])
+	line[v.cx] = c
+	v.lines[v.cy] = line
+	v.cx++
+}
+
+// EditDelete removes the character before the cursor when back is set, or the
+// character under it otherwise, joining lines at their boundaries
+func (v *View) EditDelete(back bool) {
+	v.ensureLine(v.cy)
+	line := v.lines[v.cy]
+	if v.cx > len(line) {
+		v.cx = len(line)
+	}
+	if back {
+		if v.cx == 0 {
+			if v.cy == 0 {
+				return
+			}
+			prev := v.lines[v.cy-1]
+			v.cx = len(prev)
+			v.lines[v.cy-1] = append(prev, line...)
+			v.lines = append(v.lines[:v.cy], v.lines[v.cy+1:]...)
+			v.cy--
+			return
+		}
+		v.lines[v.cy] = append(line[:v.cx-1], line[v.cx:]...)
+		v.cx--
+		return
+	}
+	if v.cx == len(line) {
+		if v.cy+1 < len(v.lines) {
+			v.lines[v.cy] = append(line, v.lines[v.cy+1]...)
+			v.lines = append(v.lines[:v.cy+1], v.lines[v.cy+2:]...)
+		}
+		return
+	}
+	v.lines[v.cy] = append(line[:v.cx], line[v.cx+1:]...)
+}
+
+// EditNewLine splits the current line at the cursor
+func (v *View) EditNewLine() {
+	v.ensureLine(v.cy)
+	line := v.lines[v.cy]
+	if v.cx > len(line) {
+		v.cx = len(line)
+	}
+	rest := append([]cell(nil), line[v.cx:]...)
+	v.lines[v.cy] = line[:v.cx]
+	v.lines = append(v.lines, nil)
+	copy(v.lines[v.cy+2:], v.lines[v.cy+1:])
+	v.lines[v.cy+1] = rest
+	v.cy++
+	v.cx = 0
+}
+
+// MoveCursor moves the cursor of an editable view by dx columns and dy lines,
+// wrapping horizontally across line boundaries
+func (v *View) MoveCursor(dx, dy int) {
+	if dy != 0 {
+		y := v.cy + dy
+		if y < 0 || y >= len(v.lines) {
+			return
+		}
+		v.cy = y
+		if n := len(v.lines[y]); v.cx > n {
+			v.cx = n
+		}
+		return
+	}
+
+	x := v.cx + dx
+	switch {
+	case x < 0:
+		if v.cy > 0 {
+			v.cy--
+			v.cx = len(v.line(v.cy))
+		}
+	case x > len(v.line(v.cy)):
+		if v.cy+1 < len(v.lines) {
+			v.cy++
+			v.cx = 0
+		}
+	default:
+		v.cx = x
+	}
+}
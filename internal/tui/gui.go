@@ -0,0 +1,350 @@
+// Package tui is a small terminal UI toolkit on top of tcell. It keeps the
+// view, keybinding, and layout-manager model of gocui, which lazylinear was
+// originally written against, while adding full Unicode width handling and
+// 256-color and true-color ANSI output.
+package tui
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	// ErrQuit is returned by a handler to stop the main loop
+	ErrQuit = errors.New("quit")
+
+	// ErrUnknownView is returned by View, DeleteView, and SetCurrentView when
+	// the view does not exist, and by SetView when it creates a new view
+	ErrUnknownView = errors.New("unknown view")
+)
+
+// Gui owns the terminal screen, the views laid out on it, and the keybindings
+type Gui struct {
+	screen      tcell.Screen
+	views       []*View
+	currentView *View
+	keybindings []*keybinding
+	manager     func(*Gui) error
+	userEvents  chan func(*Gui) error
+	closed      bool
+
+	// Highlight draws the current view's frame in SelFgColor
+	Highlight bool
+
+	// BgColor and FgColor are the default colors of frames and new views
+	BgColor, FgColor Attribute
+
+	// SelBgColor and SelFgColor color the current view's frame when Highlight is set
+	SelBgColor, SelFgColor Attribute
+}
+
+// NewGui initializes the terminal and returns a new Gui
+func NewGui() (*Gui, error) {
+	s, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Init(); err != nil {
+		return nil, err
+	}
+	return &Gui{
+		screen:     s,
+		userEvents: make(chan func(*Gui) error, 20),
+	}, nil
+}
+
+// Close restores the terminal
+func (g *Gui) Close() {
+	if g.closed {
+		return
+	}
+	g.closed = true
+	g.screen.Fini()
+}
+
+// Suspend hands the terminal back to the normal screen so plain output or
+// another program can use it until Resume is called
+func (g *Gui) Suspend() error {
+	return g.screen.Suspend()
+}
+
+// Resume takes the terminal back after Suspend and redraws everything
+func (g *Gui) Resume() error {
+	if err := g.screen.Resume(); err != nil {
+		return err
+	}
+	g.screen.Sync()
+	return nil
+}
+
+// Beep rings the terminal bell
+func (g *Gui) Beep() error {
+	return g.screen.Beep()
+}
+
+// Size returns the terminal's width and height
+func (g *Gui) Size() (x, y int) {
+	return g.screen.Size()
+}
+
+// SetView creates or resizes the named view. When the view is created it is
+// returned together with ErrUnknownView so the caller can initialize it.
+// Dimensions that collapse on a very small terminal are clamped to an empty
+// view rather than rejected, so layouts survive any resize.
+func (g *Gui) SetView(name string, x0, y0, x1, y1 int) (*View, error) {
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	if v, err := g.View(name); err == nil {
+		v.x0, v.y0, v.x1, v.y1 = x0, y0, x1, y1
+		return v, nil
+	}
+
+	v := newView(name, x0, y0, x1, y1)
+	v.BgColor, v.FgColor = g.BgColor, g.FgColor
+	v.SelBgColor, v.SelFgColor = g.SelBgColor, g.SelFgColor
+	g.views = append(g.views, v)
+	return v, ErrUnknownView
+}
+
+// View returns the named view
+func (g *Gui) View(name string) (*View, error) {
+	for _, v := range g.views {
+		if v.name == name {
+			return v, nil
+		}
+	}
+	return nil, ErrUnknownView
+}
+
+// DeleteView removes the named view. Its keybindings are kept so they apply
+// again if the view is recreated.
+func (g *Gui) DeleteView(name string) error {
+	for i, v := range g.views {
+		if v.name != name {
+			continue
+		}
+		g.views = append(g.views[:i], g.views[i+1:]...)
+		if g.currentView == v {
+			g.currentView = nil
+		}
+		return nil
+	}
+	return ErrUnknownView
+}
+
+// SetCurrentView focuses the named view
+func (g *Gui) SetCurrentView(name string) (*View, error) {
+	v, err := g.View(name)
+	if err != nil {
+		return nil, err
+	}
+	g.currentView = v
+	return v, nil
+}
+
+// CurrentView returns the focused view, or nil
+func (g *Gui) CurrentView() *View {
+	return g.currentView
+}
+
+// SetKeybinding binds key, a Key or a rune, in the named view to handler. An
+// empty view name binds the key globally.
+func (g *Gui) SetKeybinding(viewName string, key interface{}, mod Modifier, handler func(*Gui, *View) error) error {
+	k, ch, err := getKey(key)
+	if err != nil {
+		return err
+	}
+	g.keybindings = append(g.keybindings, &keybinding{
+		viewName: viewName,
+		key:      k,
+		ch:       ch,
+		mod:      mod,
+		handler:  handler,
+	})
+	return nil
+}
+
+// HasKeybinding reports whether key, a Key or a rune, is bound without
+// modifiers in the named view or globally
+func (g *Gui) HasKeybinding(viewName string, key interface{}) bool {
+	k, ch, err := getKey(key)
+	if err != nil {
+		return false
+	}
+	for _, kb := range g.keybindings {
+		if kb.key == k && kb.ch == ch && kb.mod == ModNone && (kb.viewName == viewName || kb.viewName == "") {
+			return true
+		}
+	}
+	return false
+}
+
+// SetManagerFunc sets the layout function, which runs before every redraw
+func (g *Gui) SetManagerFunc(manager func(*Gui) error) {
+	g.manager = manager
+}
+
+// Update schedules f to run on the main loop. It is safe to call from any
+// goroutine.
+func (g *Gui) Update(f func(*Gui) error) {
+	go func() { g.userEvents <- f }()
+}
+
+// MainLoop draws the screen and dispatches events until a handler returns
+// ErrQuit or another error
+func (g *Gui) MainLoop() error {
+	events := make(chan tcell.Event, 20)
+	quit := make(chan struct{})
+	defer close(quit)
+	go g.screen.ChannelEvents(events, quit)
+
+	if err := g.flush(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case ev := <-events:
+			if err := g.handleEvent(ev); err != nil {
+				return err
+			}
+		case f := <-g.userEvents:
+			if err := f(g); err != nil {
+				return err
+			}
+		}
+		if err := g.drainEvents(events); err != nil {
+			return err
+		}
+		if err := g.flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// drainEvents handles queued events without redrawing in between
+func (g *Gui) drainEvents(events chan tcell.Event) error {
+	for {
+		select {
+		case ev := <-events:
+			if err := g.handleEvent(ev); err != nil {
+				return err
+			}
+		case f := <-g.userEvents:
+			if err := f(g); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// handleEvent dispatches a single tcell event
+func (g *Gui) handleEvent(ev tcell.Event) error {
+	switch ev := ev.(type) {
+	case *tcell.EventKey:
+		return g.handleKey(ev)
+	case *tcell.EventResize:
+		g.screen.Sync()
+	}
+	return nil
+}
+
+// handleKey runs every keybinding matching the key in the current view or
+// globally, and passes the key to the current view's editor if none matched
+func (g *Gui) handleKey(ev *tcell.EventKey) error {
+	key, ch, mod := translateKey(ev)
+	v := g.currentView
+
+	matched := false
+	for _, kb := range append([]*keybinding(nil), g.keybindings...) {
+		if kb.key != key || kb.ch != ch || kb.mod != mod || !kb.matchView(v) {
+			continue
+		}
+		if err := kb.handler(g, v); err != nil {
+			return err
+		}
+		matched = true
+	}
+	if !matched && v != nil && v.Editable && v.Editor != nil {
+		v.Editor.Edit(v, key, ch, mod)
+	}
+	return nil
+}
+
+// flush runs the layout and redraws every view
+func (g *Gui) flush() error {
+	g.screen.Clear()
+	if g.manager != nil {
+		if err := g.manager(g); err != nil {
+			return err
+		}
+	}
+
+	g.screen.HideCursor()
+	for _, v := range g.views {
+		if v.Frame {
+			g.drawFrame(v)
+		}
+		cx, cy, ok := v.draw(g.screen)
+		if ok && v == g.currentView {
+			g.screen.ShowCursor(cx, cy)
+		}
+	}
+	g.screen.Show()
+	return nil
+}
+
+// drawFrame draws a view's border and title
+func (g *Gui) drawFrame(v *View) {
+	fg := g.FgColor
+	if g.Highlight && v == g.currentView {
+		fg = g.SelFgColor
+	}
+	st := style(fg, g.BgColor)
+
+	for x := v.x0 + 1; x < v.x1; x++ {
+		g.screen.SetContent(x, v.y0, '─', nil, st)
+		g.screen.SetContent(x, v.y1, '─', nil, st)
+	}
+	for y := v.y0 + 1; y < v.y1; y++ {
+		g.screen.SetContent(v.x0, y, '│', nil, st)
+		g.screen.SetContent(v.x1, y, '│', nil, st)
+	}
+	g.screen.SetContent(v.x0, v.y0, '┌', nil, st)
+	g.screen.SetContent(v.x1, v.y0, '┐', nil, st)
+	g.screen.SetContent(v.x0, v.y1, '└', nil, st)
+	g.screen.SetContent(v.x1, v.y1, '┘', nil, st)
+
+	if v.Title != "" {
+		x := v.x0 + 2
+		for _, r := range v.Title {
+			w := runewidth.RuneWidth(r)
+			if x+w > v.x1-1 {
+				break
+			}
+			g.screen.SetContent(x, v.y0, r, nil, st)
+			x += w
+		}
+	}
+	if v.Footer != "" {
+		x := v.x1 - 1 - runewidth.StringWidth(v.Footer)
+		if x < v.x0+1 {
+			x = v.x0 + 1
+		}
+		for _, r := range v.Footer {
+			w := runewidth.RuneWidth(r)
+			if x+w > v.x1 {
+				break
+			}
+			g.screen.SetContent(x, v.y1, r, nil, st)
+			x += w
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Key is a special (non-printable) key
+type Key tcell.Key
+
+// Special keys. Control keys share their ASCII codes, so KeyTab equals
+// KeyCtrlI and KeyEnter equals KeyCtrlM, as in a terminal.
+const (
+	KeyArrowUp    = Key(tcell.KeyUp)
+	KeyArrowDown  = Key(tcell.KeyDown)
+	KeyArrowLeft  = Key(tcell.KeyLeft)
+	KeyArrowRight = Key(tcell.KeyRight)
+	KeyPgup       = Key(tcell.KeyPgUp)
+	KeyPgdn       = Key(tcell.KeyPgDn)
+	KeyHome       = Key(tcell.KeyHome)
+	KeyEnd        = Key(tcell.KeyEnd)
+	KeyInsert     = Key(tcell.KeyInsert)
+	KeyDelete     = Key(tcell.KeyDelete)
+	KeyBacktab    = Key(tcell.KeyBacktab)
+	KeyF1         = Key(tcell.KeyF1)
+	KeyF5         = Key(tcell.KeyF5)
+
+	KeyEnter      = Key(tcell.KeyEnter)
+	KeyEsc        = Key(tcell.KeyEsc)
+	KeyTab        = Key(tcell.KeyTab)
+	KeyBackspace  = Key(tcell.KeyBackspace)
+	KeyBackspace2 = Key(tcell.KeyBackspace2)
+	KeySpace      = Key(' ')
+
+	KeyCtrlA = Key(tcell.KeyCtrlA)
+	KeyCtrlB = Key(tcell.KeyCtrlB)
+	KeyCtrlC = Key(tcell.KeyCtrlC)
+	KeyCtrlD = Key(tcell.KeyCtrlD)
+	KeyCtrlE = Key(tcell.KeyCtrlE)
+	KeyCtrlF = Key(tcell.KeyCtrlF)
+	KeyCtrlG = Key(tcell.KeyCtrlG)
+	KeyCtrlK = Key(tcell.KeyCtrlK)
+	KeyCtrlL = Key(tcell.KeyCtrlL)
+	KeyCtrlN = Key(tcell.KeyCtrlN)
+	KeyCtrlO = Key(tcell.KeyCtrlO)
+	KeyCtrlP = Key(tcell.KeyCtrlP)
+	KeyCtrlQ = Key(tcell.KeyCtrlQ)
+	KeyCtrlR = Key(tcell.KeyCtrlR)
+	KeyCtrlS = Key(tcell.KeyCtrlS)
+	KeyCtrlT = Key(tcell.KeyCtrlT)
+	KeyCtrlU = Key(tcell.KeyCtrlU)
+	KeyCtrlW = Key(tcell.KeyCtrlW)
+	KeyCtrlX = Key(tcell.KeyCtrlX)
+	KeyCtrlY = Key(tcell.KeyCtrlY)
+	KeyCtrlZ = Key(tcell.KeyCtrlZ)
+)
+
+// Modifier is a key modifier
+type Modifier int
+
+// Supported modifiers
+const (
+	ModNone Modifier = iota
+	ModAlt
+)
+
+// keybinding maps a key press in a view to a handler
+type keybinding struct {
+	viewName string
+	key      Key
+	ch       rune
+	mod      Modifier
+	handler  func(*Gui, *View) error
+}
+
+// matchView reports whether the binding applies to v; bindings registered
+// with an empty view name are global
+func (kb *keybinding) matchView(v *View) bool {
+	if kb.viewName == "" {
+		return true
+	}
+	return v != nil && v.name == kb.viewName
+}
+
+// getKey splits a binding key given as a Key or a rune
+func getKey(key interface{}) (Key, rune, error) {
+	switch t := key.(type) {
+	case Key:
+		return t, 0, nil
+	case rune:
+		return 0, t, nil
+	default:
+		return 0, 0, errors.New("unknown key type")
+	}
+}
+
+// translateKey converts a tcell key event into a key, rune, and modifier.
+// Printable characters are reported as runes with a zero key, except space
+// which is reported as KeySpace.
+func translateKey(ev *tcell.EventKey) (Key, rune, Modifier) {
+	mod := ModNone
+	if ev.Modifiers()&tcell.ModAlt != 0 {
+		mod = ModAlt
+	}
+	if ev.Key() == tcell.KeyRune {
+		if ev.Rune() == ' ' {
+			return KeySpace, 0, mod
+		}
+		return 0, ev.Rune(), mod
+	}
+	return Key(ev.Key()), 0, mod
+}
@@ -0,0 +1,347 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+var errInvalidPoint = errors.New("invalid point")
+
+// cell is a single character in a view's buffer. Zero-width runes such as
+// variation selectors and combining marks are attached to the preceding cell.
+type cell struct {
+	ch    rune
+	comb  []rune
+	width int
+	style tcell.Style
+}
+
+// displayLine is a row as drawn on screen: a slice of a buffer line, which
+// may be split across several rows when the view wraps
+type displayLine struct {
+	line  int // index into View.lines
+	cells []cell
+}
+
+// A View is a rectangular pane with its own buffer, cursor, and origin
+type View struct {
+	name           string
+	x0, y0, x1, y1 int
+	ox, oy         int
+	cx, cy         int
+	lines          [][]cell
+	pending        []byte
+	ansi           ansiState
+
+	// BgColor and FgColor are the default colors of the view's content
+	BgColor, FgColor Attribute
+
+	// SelBgColor and SelFgColor color the line under the cursor when Highlight is set
+	SelBgColor, SelFgColor Attribute
+
+	// Editable views receive unbound key presses through their Editor
+	Editable bool
+	Editor   Editor
+
+	// Highlight colors the line under the cursor with Sel{Bg,Fg}Color
+	Highlight bool
+
+	// Frame draws a border around the view
+	Frame bool
+
+	// Wrap soft-wraps lines longer than the view's width
+	Wrap bool
+
+	// Title is drawn in the top border when Frame is set
+	Title string
+
+	// Footer is drawn right-aligned in the bottom border when Frame is set
+	Footer string
+}
+
+func newView(name string, x0, y0, x1, y1 int) *View {
+	return &View{
+		name:   name,
+		x0:     x0,
+		y0:     y0,
+		x1:     x1,
+		y1:     y1,
+		Frame:  true,
+		Editor: DefaultEditor,
+	}
+}
+
+// Name returns the name of the view
+func (v *View) Name() string {
+	return v.name
+}
+
+// Size returns the number of visible columns and rows in the view
+func (v *View) Size() (x, y int) {
+	return v.x1 - v.x0 - 1, v.y1 - v.y0 - 1
+}
+
+// Cursor returns the cursor position. For editable views it is the position
+// in the buffer (column, line); otherwise it is relative to the origin.
+func (v *View) Cursor() (x, y int) {
+	return v.cx, v.cy
+}
+
+// SetCursor moves the cursor. Non-editable views reject points outside the
+// visible area so callers can scroll the origin instead.
+func (v *View) SetCursor(x, y int) error {
+	if x < 0 || y < 0 {
+		return errInvalidPoint
+	}
+	if !v.Editable {
+		maxX, maxY := v.Size()
+		if x >= maxX || y >= maxY {
+			return errInvalidPoint
+		}
+	}
+	v.cx, v.cy = x, y
+	return nil
+}
+
+// Origin returns the first visible column and row
+func (v *View) Origin() (x, y int) {
+	return v.ox, v.oy
+}
+
+// SetOrigin scrolls the view so (x, y) is the first visible column and row
+func (v *View) SetOrigin(x, y int) error {
+	if x < 0 || y < 0 {
+		return errInvalidPoint
+	}
+	v.ox, v.oy = x, y
+	return nil
+}
+
+// Clear empties the view's buffer
+func (v *View) Clear() {
+	v.lines = nil
+	v.pending = nil
+	v.ansi = ansiState{}
+}
+
+// Write appends text to the view's buffer, interpreting ANSI SGR sequences
+// for colors and styles. It implements io.Writer.
+func (v *View) Write(p []byte) (int, error) {
+	data := append(v.pending, p...)
+	v.pending = nil
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 && !utf8.FullRune(data) {
+			v.pending = append([]byte(nil), data...)
+			break
+		}
+		data = data[size:]
+		v.writeRune(r)
+	}
+	return len(p), nil
+}
+
+// writeRune appends one decoded rune to the buffer
+func (v *View) writeRune(r rune) {
+	if v.ansi.consume(r) {
+		return
+	}
+	switch r {
+	case '\n':
+		v.lines = append(v.lines, nil)
+		return
+	case '\r':
+		if n := len(v.lines); n > 0 {
+			v.lines[n-1] = nil
+		}
+		return
+	case '\t':
+		for i := 0; i < 4; i++ {
+			v.appendCell(cell{ch: ' ', width: 1, style: v.ansi.style(v.FgColor, v.BgColor)})
+		}
+		return
+	}
+
+	width := runewidth.RuneWidth(r)
+	if width == 0 {
+		// Attach combining runes to the previous cell
+		if n := len(v.lines); n > 0 && len(v.lines[n-1]) > 0 {
+			last := &v.lines[n-1][len(v.lines[n-1])-1]
+			last.comb = append(last.comb, r)
+			// A variation selector requests emoji presentation, which is double width
+			if r == '\uFE0F' && last.width == 1 {
+				last.width = 2
+			}
+		}
+		return
+	}
+	v.appendCell(cell{ch: r, width: width, style: v.ansi.style(v.FgColor, v.BgColor)})
+}
+
+func (v *View) appendCell(c cell) {
+	if len(v.lines) == 0 {
+		v.lines = append(v.lines, nil)
+	}
+	n := len(v.lines) - 1
+	v.lines[n] = append(v.lines[n], c)
+}
+
+// Buffer returns the view's text content without styling, one line per buffer line
+func (v *View) Buffer() string {
+	var b strings.Builder
+	for _, line := range v.lines {
+		b.WriteString(lineString(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// BufferLines returns the view's text content split into lines
+func (v *View) BufferLines() []string {
+	lines := make([]string, len(v.lines))
+	for i, line := range v.lines {
+		lines[i] = lineString(line)
+	}
+	return lines
+}
+
+func lineString(line []cell) string {
+	var b strings.Builder
+	for _, c := range line {
+		b.WriteRune(c.ch)
+		for _, r := range c.comb {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Rows returns the number of screen rows the view's content takes up once
+// wrapped
+func (v *View) Rows() int {
+	return len(v.displayLines())
+}
+
+// displayLines splits the buffer into screen rows, wrapping by display width
+func (v *View) displayLines() []displayLine {
+	maxX, _ := v.Size()
+	var rows []displayLine
+	for i, line := range v.lines {
+		if !v.Wrap || maxX <= 0 {
+			rows = append(rows, displayLine{line: i, cells: line})
+			continue
+		}
+		start, width := 0, 0
+		for j, c := range line {
+			if width+c.width > maxX {
+				rows = append(rows, displayLine{line: i, cells: line[start:j]})
+				start, width = j, 0
+			}
+			width += c.width
+		}
+		rows = append(rows, displayLine{line: i, cells: line[start:]})
+	}
+	return rows
+}
+
+// editCursorRow returns the display row and column of an editable view's
+// buffer cursor
+func (v *View) editCursorRow(rows []displayLine) (int, int) {
+	col := v.cx
+	for i, row := range rows {
+		if row.line != v.cy {
+			continue
+		}
+		if col <= len(row.cells) {
+			// Place the cursor on the next row when it sits exactly at a wrap point
+			if col == len(row.cells) && i+1 < len(rows) && rows[i+1].line == v.cy {
+				col -= len(row.cells)
+				continue
+			}
+			x := 0
+			for _, c := range row.cells[:col] {
+				x += c.width
+			}
+			return i, x
+		}
+		col -= len(row.cells)
+	}
+	return len(rows), 0
+}
+
+// draw renders the view's content into the screen area inside its frame
+func (v *View) draw(s tcell.Screen) (cursorX, cursorY int, cursorVisible bool) {
+	maxX, maxY := v.Size()
+	base := style(v.FgColor, v.BgColor)
+	for y := 0; y < maxY; y++ {
+		for x := 0; x < maxX; x++ {
+			s.SetContent(v.x0+1+x, v.y0+1+y, ' ', nil, base)
+		}
+	}
+
+	rows := v.displayLines()
+	if v.Wrap {
+		v.ox = 0
+	}
+
+	// Editable views scroll to keep the buffer cursor visible
+	curRow, curCol := -1, 0
+	if v.Editable {
+		curRow, curCol = v.editCursorRow(rows)
+		if curRow < v.oy {
+			v.oy = curRow
+		} else if curRow >= v.oy+maxY {
+			v.oy = curRow - maxY + 1
+		}
+	}
+
+	highlightLine := -1
+	if v.Highlight && !v.Editable {
+		if idx := v.oy + v.cy; idx < len(rows) {
+			highlightLine = rows[idx].line
+		}
+	}
+	selStyle := style(v.SelFgColor, v.SelBgColor)
+
+	for y := 0; y < maxY; y++ {
+		idx := v.oy + y
+		if idx >= len(rows) {
+			break
+		}
+		row := rows[idx]
+		highlighted := row.line == highlightLine
+		if highlighted {
+			for x := 0; x < maxX; x++ {
+				s.SetContent(v.x0+1+x, v.y0+1+y, ' ', nil, selStyle)
+			}
+		}
+
+		x := 0
+		skip := v.ox
+		for _, c := range row.cells {
+			if skip > 0 {
+				skip -= c.width
+				continue
+			}
+			if x+c.width > maxX {
+				break
+			}
+			st := c.style
+			if highlighted {
+				_, _, attrs := c.style.Decompose()
+				st = selStyle.Attributes(attrs)
+			}
+			s.SetContent(v.x0+1+x, v.y0+1+y, c.ch, c.comb, st)
+			x += c.width
+		}
+	}
+
+	if v.Editable && curRow >= v.oy && curRow < v.oy+maxY && curCol < maxX {
+		return v.x0 + 1 + curCol, v.y0 + 1 + curRow - v.oy, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+// agingAfter returns how long an issue can go without updates before it is
+// badged as aging
+func (ui *UI) agingAfter() time.Duration {
+	if ui.config.AgingDays > 0 {
+		return time.Duration(ui.config.AgingDays) * 24 * time.Hour
+	}
+	return 14 * 24 * time.Hour
+}
+
+// ageBadge renders the days since an unresolved issue was last updated, like
+// "⌛21d ", once it is aging, or "" otherwise
+func (ui *UI) ageBadge(issue api.Issue) string {
+	if issue.CompletedAt != "" || issue.CanceledAt != "" {
+		return ""
+	}
+	updated, ok := parseTimestamp(issue.UpdatedAt)
+	if !ok {
+		return ""
+	}
+	age := time.Since(updated)
+	if age < ui.agingAfter() {
+		return ""
+	}
+	return colorize(ui.theme().aging, "⌛"+formatRemaining(age)) + " "
+}
+
+func (ui *UI) toggleStalest(g *tui.Gui, v *tui.View) error {
+	ui.store.UpdateFilter(func(f *store.Filter) { f.Stalest = !f.Stalest })
+	return nil
+}
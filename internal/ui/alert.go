@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// alertModes are the alert choices cycled on the settings screen
+var alertModes = []string{"off", "bell", "flash", "both"}
+
+// flashDuration is how long the status bar stays inverted after an alert
+const flashDuration = time.Second
+
+// alertMode returns the configured alert mode, defaulting to off
+func (ui *UI) alertMode() string {
+	for _, mode := range alertModes {
+		if mode == ui.config.Alert {
+			return mode
+		}
+	}
+	return "off"
+}
+
+// checkAlerts compares a background refresh against the previous issues and
+// checks notifications fetched at checked for new mentions, alerting once if
+// anything needs attention
+func (ui *UI) checkAlerts(g *tui.Gui, before []api.Issue, notifications []api.Notification, checked time.Time) {
+	mode := ui.alertMode()
+	if mode == "off" {
+		return
+	}
+
+	var events []string
+	if changed := ui.watchedChanges(before, ui.store.All()); len(changed) > 0 {
+		events = append(events, strings.Join(changed, ", ")+" changed")
+	}
+	if mentions := ui.newMentions(notifications, checked); len(mentions) > 0 {
+		events = append(events, fmt.Sprintf("%d new mention(s) from %s", len(mentions), strings.Join(mentions, ", ")))
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	if mode == "bell" || mode == "both" {
+		g.Beep()
+	}
+	if mode == "flash" || mode == "both" {
+		ui.flashUntil = time.Now().Add(flashDuration)
+		time.AfterFunc(flashDuration, func() {
+			ui.gui.Update(func(g *tui.Gui) error { return nil })
+		})
+	}
+	ui.notify("%s", strings.Join(events, "; "))
+}
+
+// watchedChanges returns the identifiers of the viewer's issues that were
+// updated between two loads, such as by a state change or a new comment,
+// including newly assigned ones
+func (ui *UI) watchedChanges(before, after []api.Issue) []string {
+	previous := make(map[string]api.Issue, len(before))
+	for _, issue := range before {
+		previous[issue.ID] = issue
+	}
+
+	var changed []string
+	for _, issue := range after {
+		if ui.viewerID == "" || issue.Assignee.ID != ui.viewerID {
+			continue
+		}
+		old, ok := previous[issue.ID]
+		if !ok || old.Assignee.ID != issue.Assignee.ID || old.State.Name != issue.State.Name ||
+			old.UpdatedAt != issue.UpdatedAt {
+			changed = append(changed, issue.Identifier)
+		}
+	}
+	return changed
+}
+
+// newMentions returns the actors of unread mentions received since the last
+// check. Nil notifications, such as after a failed fetch, leave the last
+// check time alone so nothing is missed.
+func (ui *UI) newMentions(notifications []api.Notification, checked time.Time) []string {
+	if notifications == nil {
+		return nil
+	}
+	since := ui.alertSince
+	ui.alertSince = checked
+
+	var actors []string
+	for _, n := range notifications {
+		if n.ReadAt != "" || categorize(n.Type) != 0 {
+			continue
+		}
+		if at, err := time.Parse(time.RFC3339, n.CreatedAt); err == nil && at.After(since) {
+			actors = append(actors, n.Actor.Name)
+		}
+	}
+	return actors
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"context"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/store"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// allTeamsConcurrency caps the team issue lists fetched at once for the
+// All teams list
+const allTeamsConcurrency = 4
+
+// allTeams reports whether the list merges the issues of every team
+func (ui *UI) allTeams() bool {
+	return ui.store.TeamIndex() == store.AllTeams
+}
+
+// fetchAllTeams merges the issue lists of every team in the team bar, which
+// honors the teams and hidden_teams settings. Fresh cached lists are reused
+// unless force is set; the others are fetched concurrently and cached.
+func (ui *UI) fetchAllTeams(force bool) ([]api.Issue, error) {
+	teams := ui.store.Teams()
+	lists := make([][]api.Issue, len(teams))
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(allTeamsConcurrency)
+	for i, team := range teams {
+		if !force {
+			if issues, _, ok := ui.teamCache.get(team.ID); ok {
+				lists[i] = issues
+				continue
+			}
+		}
+		group.Go(func() error {
+			issues, err := ui.client.GetIssues(ctx, team.ID)
+			if err != nil {
+				return err
+			}
+			ui.teamCache.store(team.ID, issues, true)
+			lists[i] = issues
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	var merged []api.Issue
+	for _, issues := range lists {
+		merged = append(merged, issues...)
+	}
+	ui.client.SortByState(merged)
+	return merged, nil
+}
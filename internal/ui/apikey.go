@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"lazylinear/internal/api"
+)
+
+// unauthorized reports whether the last load failed because Linear rejected
+// the API key or none is configured
+func (ui *UI) unauthorized() bool {
+	return errors.Is(ui.loadErr, api.ErrUnauthorized)
+}
+
+// maskedAPIKey shows only the last characters of the configured key
+func (ui *UI) maskedAPIKey() string {
+	key := ui.config.APIKey
+	if key == "" {
+		return "(missing)"
+	}
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// submitAPIKey switches to the entered key and reloads everything with it
+func (ui *UI) submitAPIKey(key string) error {
+	if ui.client == nil {
+		return nil
+	}
+	if err := ui.connect(key); err != nil {
+		return err
+	}
+	if ui.loadErr != nil {
+		ui.notifyError("Connecting", ui.loadErr)
+		return nil
+	}
+	if err := ui.config.Save(); err != nil {
+		ui.notifyError("Saving config", err)
+	}
+	ui.showSettings = false
+	ui.gui.DeleteView("settings")
+	ui.gui.SetCurrentView("issues")
+	ui.notify("Connected to Linear")
+	return nil
+}
+
+// connect applies a new API key and reloads the teams, the viewer, and the
+// issues without restarting
+func (ui *UI) connect(apiKey string) error {
+	// The old key's prefetch worker must not fill the cache with its teams
+	if ui.stopPrefetch != nil {
+		ui.stopPrefetch()
+		ui.stopPrefetch = nil
+	}
+	ui.client.SetAPIKey(apiKey)
+	ui.config.APIKey = apiKey
+
+	if teams, err := ui.client.GetTeams(context.Background()); err == nil {
+		teams = filterTeams(teams, ui.config)
+		current := 0
+		if len(teams) > 0 {
+			current = findTeam(teams, ui.config.DefaultTeam)
+		}
+		ui.store.SetTeams(teams, current)
+	}
+	if viewer, err := ui.client.GetViewer(context.Background()); err == nil {
+		ui.viewer = viewer
+		ui.viewerID = viewer.ID
+		ui.store.SetViewer(viewer.ID)
+	}
+	ui.teamCache.clear()
+	ui.members = make(map[string][]api.User)
+	ui.startPrefetch()
+	return ui.refreshIssues(ui.gui, nil)
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// archiveDoneSubIssues asks for confirmation and then archives every
+// completed sub-issue of the selected issue in one batch
+func (ui *UI) archiveDoneSubIssues(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil || ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+
+	children, err := ui.client.GetSubIssues(context.Background(), issue.ID)
+	if err != nil {
+		ui.notifyError("Loading sub-issues", err)
+		return nil
+	}
+	var done []api.RelatedIssue
+	for _, child := range children {
+		if child.State.Type == "completed" {
+			done = append(done, child)
+		}
+	}
+	if len(done) == 0 {
+		ui.notify("%s has no done sub-issues to archive", issue.Identifier)
+		return nil
+	}
+
+	allowed, reason := ui.canEditIssue(issue.Team.ID)
+	items := []pickerItem{
+		{label: "Cancel"},
+		{label: fmt.Sprintf("Archive %d done sub-issues", len(done)), value: "archive", disabled: !allowed, reason: reason},
+	}
+	for _, child := range done {
+		items = append(items, pickerItem{label: "  " + child.Identifier + " " + ui.text(child.Title), disabled: true})
+	}
+
+	ui.openPicker(fmt.Sprintf("Archive done sub-issues of %s?", issue.Identifier), items, func(item pickerItem) error {
+		if item.value != "archive" {
+			return nil
+		}
+		archived := 0
+		var archiveErr error
+		for _, child := range done {
+			if archiveErr = ui.client.ArchiveIssue(context.Background(), child.ID); archiveErr != nil {
+				break
+			}
+			archived++
+		}
+		if archived > 0 {
+			restore := done[:archived]
+			ui.pushUndo(fmt.Sprintf("archiving %d sub-issues of %s", archived, issue.Identifier), func(ctx context.Context) error {
+				for _, child := range restore {
+					if err := ui.client.UnarchiveIssue(ctx, child.ID); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		if err := ui.refreshIssues(g, v); err != nil {
+			return err
+		}
+		if archiveErr != nil {
+			ui.notifyError(fmt.Sprintf("Archiving (%d of %d done)", archived, len(done)), archiveErr)
+		} else {
+			ui.notify("Archived %d sub-issues of %s", archived, issue.Identifier)
+		}
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// attachmentLabel describes an attachment as "[Source] Title (status)"
+func (ui *UI) attachmentLabel(a api.Attachment) string {
+	label := ui.text(a.Title)
+	if label == "" {
+		label = a.URL
+	}
+	if source := a.SourceType; source != "" {
+		label = fmt.Sprintf("[%s] %s", strings.ToUpper(source[:1])+source[1:], label)
+	}
+	if status := a.Status(); status != "" {
+		label += " (" + status + ")"
+	} else if a.Subtitle != "" {
+		label += " — " + ui.text(a.Subtitle)
+	}
+	return label
+}
+
+// renderAttachments writes the attachments section of the details pane
+func (ui *UI) renderAttachments(w io.Writer, issue api.Issue) {
+	if len(issue.Attachments.Nodes) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nAttachments (L to open):")
+	for _, a := range issue.Attachments.Nodes {
+		fmt.Fprintf(w, "- %s\n", ui.attachmentLabel(a))
+	}
+}
+
+// openAttachment picks one of the selected issue's attachments and opens it
+// in the browser
+func (ui *UI) openAttachment(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	var items []pickerItem
+	for _, a := range issue.Attachments.Nodes {
+		if a.URL != "" {
+			items = append(items, pickerItem{label: ui.attachmentLabel(a), value: a.URL})
+		}
+	}
+	if len(items) == 0 {
+		ui.notify("%s has no attachments", issue.Identifier)
+		return nil
+	}
+	ui.openPicker(fmt.Sprintf("Open attachment of %s", issue.Identifier), items, func(item pickerItem) error {
+		return ui.openURL(item.value)
+	})
+	return nil
+}
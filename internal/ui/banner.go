@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+
+	"lazylinear/internal/tui"
+)
+
+// errorBannerHeight is the number of rows the error banner covers, frame included
+const errorBannerHeight = 3
+
+// layoutErrorBanner draws the load error banner above the status bar, over
+// the bottom of the list so the previous issues stay visible
+func (ui *UI) layoutErrorBanner(g *tui.Gui, maxX, maxY int) error {
+	if ui.loadErr == nil {
+		g.DeleteView("error")
+		return nil
+	}
+
+	y1 := maxY - 2
+	v, err := g.SetView("error", 0, y1-errorBannerHeight+1, maxX-1, y1)
+	if err != nil && err != tui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Error (R to retry, Esc to dismiss)"
+	v.Clear()
+	message := fmt.Sprintf("Loading issues failed: %v", ui.loadErr)
+	if ui.unauthorized() {
+		message = "API key invalid or missing — press S to configure"
+	}
+	fmt.Fprintln(v, colorize(ui.theme().toastError, message))
+	return nil
+}
+
+// retryLoad refetches the current team's issues after a failure
+func (ui *UI) retryLoad(g *tui.Gui, v *tui.View) error {
+	if err := ui.refreshIssues(g, v); err != nil {
+		return err
+	}
+	if ui.loadErr == nil {
+		ui.notify("Issues reloaded")
+	}
+	return nil
+}
+
+// loadFailed shows a failed issue load in the banner. A failed refresh keeps
+// the previous list visible, but a failed team switch clears it rather than
+// list the previous team's issues under the new team.
+func (ui *UI) loadFailed(err error) {
+	ui.loadErr = err
+	if ui.listedTeam != ui.store.TeamIndex() {
+		ui.store.SetIssues(nil)
+		ui.listedTeam = ui.store.TeamIndex()
+	}
+}
+
+// dismissLoadError hides the error banner without retrying
+func (ui *UI) dismissLoadError(g *tui.Gui, v *tui.View) error {
+	ui.loadErr = nil
+	return nil
+}
@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// checkoutBranch asks for confirmation and then creates and checks out the
+// selected issue's branch in its repository
+func (ui *UI) checkoutBranch(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	branch := ui.branchName(issue)
+	if branch == "" {
+		return nil
+	}
+
+	items := []pickerItem{
+		{label: "Cancel"},
+		{label: "git checkout -b " + branch, value: "checkout"},
+	}
+	if dir := ui.repoDir(issue); dir != "" {
+		items[1].label += " in " + dir
+	}
+	if ui.config.StartOnBranch && ui.client != nil {
+		items[1].label += " and start it"
+	}
+	ui.openPicker(fmt.Sprintf("Create branch for %s?", issue.Identifier), items, func(item pickerItem) error {
+		if item.value != "checkout" {
+			return nil
+		}
+		if err := gitCheckoutNew(ui.repoDir(issue), branch); err != nil {
+			ui.notifyError("Creating branch", err)
+			return nil
+		}
+		if !ui.config.StartOnBranch || ui.client == nil {
+			ui.notify("Checked out %s", branch)
+			return nil
+		}
+		started := func() {
+			ui.notify("Checked out %s and started %s", branch, issue.Identifier)
+		}
+		if err := ui.startIssue(issue, started); err != nil {
+			ui.notifyError(fmt.Sprintf("Checked out %s, but starting %s", branch, issue.Identifier), err)
+		}
+		return nil
+	})
+	return nil
+}
+
+// startIssue moves an issue to the team's In Progress state, honoring state
+// aliases and falling back to the first started state, and assigns it to the
+// viewer, as Linear's GitHub integration does when a branch is created, and
+// starts the external time tracker. The change shows at once; started runs
+// once the server has applied it.
+func (ui *UI) startIssue(issue api.Issue, started func()) error {
+	states, err := ui.client.GetTeamStates(context.Background(), issue.Team.ID)
+	if err != nil {
+		return err
+	}
+	var target *api.WorkflowState
+	for i, state := range states {
+		if ui.client.CanonicalState(state.Name) == "In Progress" {
+			target = &states[i]
+			break
+		}
+		if target == nil && state.Type == "started" {
+			target = &states[i]
+		}
+	}
+	if target == nil {
+		return errors.New("team has no In Progress state")
+	}
+	moved := ui.stateName(issue) != "In Progress"
+	assigned := ui.viewerID != "" && issue.Assignee.ID != ui.viewerID
+	if !moved && !assigned {
+		ui.trackStart(issue)
+		started()
+		return nil
+	}
+
+	start := func(i *api.Issue) {
+		if moved {
+			i.State.ID, i.State.Name = target.ID, target.Name
+		}
+		if assigned {
+			i.Assignee.ID = ui.viewerID
+			if ui.viewer != nil {
+				i.Assignee.Name = ui.viewer.Name
+			}
+		}
+	}
+	mutate := func(ctx context.Context) error {
+		if moved {
+			if err := ui.client.SetIssueState(ctx, issue.ID, target.ID); err != nil {
+				return err
+			}
+		}
+		if assigned {
+			return ui.client.AssignIssue(ctx, issue.ID, ui.viewerID)
+		}
+		return nil
+	}
+	ui.mutateOptimistically(issue, "Starting "+issue.Identifier, start, mutate, func() {
+		ui.pushUndo("starting "+issue.Identifier, func(ctx context.Context) error {
+			if moved {
+				if err := ui.client.SetIssueState(ctx, issue.ID, issue.State.ID); err != nil {
+					return err
+				}
+			}
+			if assigned {
+				return ui.client.AssignIssue(ctx, issue.ID, issue.Assignee.ID)
+			}
+			return nil
+		})
+		ui.trackStart(issue)
+		started()
+	})
+	return nil
+}
+
+// slugPattern matches the runs of characters replaced by dashes in slugs
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxSlugLength keeps templated branch names a manageable length
+const maxSlugLength = 40
+
+// slugify lowercases s and joins its words with dashes
+func slugify(s string) string {
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+// branchName returns the git branch for an issue: the configured
+// branch_template when set, otherwise Linear's generated name
+func (ui *UI) branchName(issue api.Issue) string {
+	tmpl := ui.config.BranchTemplate
+	if tmpl == "" {
+		return issue.BranchName
+	}
+	user := ""
+	if ui.viewer != nil {
+		user = ui.viewer.DisplayName
+		if user == "" {
+			user = ui.viewer.Name
+		}
+	}
+	number := issue.Identifier
+	if i := strings.LastIndexByte(number, '-'); i >= 0 {
+		number = number[i+1:]
+	}
+	return strings.NewReplacer(
+		"{user}", slugify(user),
+		"{identifier}", strings.ToLower(issue.Identifier),
+		"{team}", strings.ToLower(issue.Team.Key),
+		"{number}", number,
+		"{slug}", slugify(issue.Title),
+	).Replace(tmpl)
+}
+
+// repoDir returns the local repository configured for an issue's project or
+// team, or "" to use the working directory
+func (ui *UI) repoDir(issue api.Issue) string {
+	dir, ok := ui.config.Repos[issue.Project.Name]
+	if !ok || issue.Project.Name == "" {
+		dir = ui.config.Repos[issue.Team.Key]
+	}
+	return expandHome(dir)
+}
+
+// gitCheckoutNew runs git checkout -b in dir (the working directory when
+// empty), returning git's own message on failure
+func gitCheckoutNew(dir, branch string) error {
+	cmd := exec.Command("git", "checkout", "-b", branch)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return errors.New(strings.TrimPrefix(msg, "fatal: "))
+		}
+		return err
+	}
+	return nil
+}
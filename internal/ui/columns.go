@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/markdown"
+)
+
+// defaultColumns are shown before the title when none are configured
+var defaultColumns = []string{"identifier", "assignee"}
+
+// listColumn renders one field of an issue list row
+type listColumn struct {
+	// maxWidth caps the column; narrower values are padded to the widest row
+	maxWidth int
+	value    func(ui *UI, issue api.Issue) string
+	color    func(t theme) string
+}
+
+// listColumns are the columns available in the "columns" config setting
+var listColumns = map[string]listColumn{
+	"identifier": {
+		maxWidth: 12,
+		value:    func(ui *UI, issue api.Issue) string { return issue.Identifier },
+		color:    func(t theme) string { return t.identifier },
+	},
+	"assignee": {
+		maxWidth: 2,
+		value:    func(ui *UI, issue api.Issue) string { return initials(issue.Assignee.Name) },
+		color:    func(t theme) string { return t.assignee },
+	},
+	"priority": {
+		maxWidth: 6,
+		value:    func(ui *UI, issue api.Issue) string { return issue.PriorityLabel() },
+	},
+	"estimate": {
+		maxWidth: 4,
+		value: func(ui *UI, issue api.Issue) string {
+			if issue.Estimate == nil {
+				return ""
+			}
+			return strconv.FormatFloat(*issue.Estimate, 'f', -1, 64)
+		},
+	},
+	"labels": {
+		maxWidth: 20,
+		value: func(ui *UI, issue api.Issue) string {
+			names := make([]string, len(issue.Labels.Nodes))
+			for i, label := range issue.Labels.Nodes {
+				names[i] = label.Name
+			}
+			return strings.Join(names, ",")
+		},
+	},
+	"project": {
+		maxWidth: 20,
+		value:    func(ui *UI, issue api.Issue) string { return projectTag(issue, 15) },
+	},
+	"team": {
+		maxWidth: 5,
+		value:    func(ui *UI, issue api.Issue) string { return issue.Team.Key },
+		color:    func(t theme) string { return t.identifier },
+	},
+	"updated": {
+		maxWidth: 10,
+		value:    func(ui *UI, issue api.Issue) string { return ui.formatTimestamp(issue.UpdatedAt) },
+	},
+}
+
+// columns returns the configured list columns, skipping unknown names. The
+// All teams list leads with the team key unless it is already configured.
+func (ui *UI) columns() []listColumn {
+	names := ui.config.Columns
+	if len(names) == 0 {
+		names = defaultColumns
+	}
+	if ui.allTeams() && !slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, "team") }) {
+		names = append([]string{"team"}, names...)
+	}
+	var cols []listColumn
+	for _, name := range names {
+		if col, ok := listColumns[strings.ToLower(name)]; ok {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// listRows renders the issue list, aligning each column to its widest value
+// and truncating every row to width display columns
+func (ui *UI) listRows(issues []api.Issue, width int) []string {
+	cols := ui.columns()
+	values := make([][]string, len(cols))
+	widths := make([]int, len(cols))
+	for c, col := range cols {
+		values[c] = make([]string, len(issues))
+		for i, issue := range issues {
+			value := ui.text(col.value(ui, issue))
+			values[c][i] = value
+			if w := markdown.VisibleWidth(value); w > widths[c] {
+				widths[c] = w
+			}
+		}
+		if widths[c] > col.maxWidth {
+			widths[c] = col.maxWidth
+		}
+	}
+
+	rows := make([]string, len(issues))
+	for i, issue := range issues {
+		var row strings.Builder
+		row.WriteString(ui.statusMarker(issue))
+		for c, col := range cols {
+			if widths[c] == 0 {
+				continue
+			}
+			cell := fit(values[c][i], widths[c])
+			if col.color != nil {
+				cell = colorize(col.color(ui.theme()), cell)
+			}
+			row.WriteString(cell + " ")
+		}
+		row.WriteString(ui.treeMarker(issue) + ui.ageBadge(issue) + ui.dueIndicators(issue) + progressBar(issue) + ui.text(issue.Title))
+		rows[i] = markdown.Truncate(row.String(), width)
+	}
+	return rows
+}
+
+// fit truncates or pads s to exactly width display columns
+func fit(s string, width int) string {
+	s = markdown.Truncate(s, width)
+	return s + strings.Repeat(" ", width-markdown.VisibleWidth(s))
+}
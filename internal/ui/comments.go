@@ -0,0 +1,368 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/emoji"
+	"lazylinear/internal/markdown"
+	"lazylinear/internal/tui"
+)
+
+// setCommentKeybindings registers the keys active while comments are focused
+func (ui *UI) setCommentKeybindings(g *tui.Gui) error {
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "navigate", ui.nextComment},
+		{tui.KeyArrowDown, "navigate", ui.nextComment},
+		{'k', "navigate", ui.prevComment},
+		{tui.KeyArrowUp, "navigate", ui.prevComment},
+		{'y', "copy", ui.copyComment},
+		{'l', "link", ui.copyCommentLink},
+		{'q', "quote", ui.quoteReply},
+		{'e', "edit", ui.editComment},
+		{'+', "react", ui.reactToComment},
+		{'r', "reply", ui.replyToComment},
+		{tui.KeyEnter, "fold thread", ui.toggleThread},
+		{tui.KeySpace, "fold thread", ui.toggleThread},
+		{'o', "", ui.openCommentAuthor},
+		{tui.KeyEsc, "back", ui.blurCommentList},
+		{tui.KeyTab, "back", ui.blurCommentList},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "details", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commentsFocused reports whether the comment list of a valid issue has focus
+func (ui *UI) commentsFocused() bool {
+	return ui.focusComments && ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues)
+}
+
+// commentRow is a comment as listed in the details pane: replies follow
+// their thread's root comment, indented one level
+type commentRow struct {
+	comment api.Comment
+	reply   bool
+	// replies counts the replies of a root comment
+	replies int
+}
+
+// threadRoot returns the ID of the root comment of c's thread
+func threadRoot(c api.Comment) string {
+	if c.Parent != nil {
+		return c.Parent.ID
+	}
+	return c.ID
+}
+
+// commentRows orders an issue's comments into threads, leaving out the
+// replies of collapsed threads
+func (ui *UI) commentRows(issue api.Issue) []commentRow {
+	comments := issue.Comments.Nodes
+	ids := make(map[string]bool, len(comments))
+	for _, c := range comments {
+		ids[c.ID] = true
+	}
+	replies := make(map[string][]api.Comment)
+	var roots []api.Comment
+	for _, c := range comments {
+		if c.Parent != nil && ids[c.Parent.ID] {
+			replies[c.Parent.ID] = append(replies[c.Parent.ID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].CreatedAt < roots[j].CreatedAt })
+
+	var rows []commentRow
+	for _, root := range roots {
+		thread := replies[root.ID]
+		sort.SliceStable(thread, func(i, j int) bool { return thread[i].CreatedAt < thread[j].CreatedAt })
+		rows = append(rows, commentRow{comment: root, replies: len(thread)})
+		if ui.collapsedThreads[root.ID] {
+			continue
+		}
+		for _, reply := range thread {
+			rows = append(rows, commentRow{comment: reply, reply: true})
+		}
+	}
+	return rows
+}
+
+// currentComment returns the comment under the comment cursor
+func (ui *UI) currentComment() (api.Comment, bool) {
+	if !ui.commentsFocused() {
+		return api.Comment{}, false
+	}
+	rows := ui.commentRows(ui.issues[ui.selectedIssue])
+	if ui.selectedComment < 0 || ui.selectedComment >= len(rows) {
+		return api.Comment{}, false
+	}
+	return rows[ui.selectedComment].comment, true
+}
+
+// renderComments writes the comment threads of the details pane,
+// highlighting the selected comment when comments are focused
+func (ui *UI) renderComments(w io.Writer, issue api.Issue, width int) {
+	if len(issue.Comments.Nodes) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nComments:")
+	for i, row := range ui.commentRows(issue) {
+		comment := row.comment
+		indent := ""
+		if row.reply {
+			indent = "    "
+		}
+		header := fmt.Sprintf("%s (%s):", comment.User.Name, ui.formatTimestamp(comment.CreatedAt))
+		if row.replies > 0 && ui.collapsedThreads[comment.ID] {
+			header += fmt.Sprintf(" [+%d replies]", row.replies)
+		}
+		if ui.commentsFocused() && i == ui.selectedComment {
+			fmt.Fprintf(w, "%s\033[7m▶ %s\033[0m\n", indent, header)
+		} else if row.reply {
+			fmt.Fprintf(w, "%s↳ %s\n", indent, header)
+		} else {
+			fmt.Fprintf(w, "- %s\n", header)
+		}
+		if reactions := ui.reactionSummary(comment); reactions != "" {
+			fmt.Fprintf(w, "%s  %s\n", indent, reactions)
+		}
+		body := markdown.Render(ui.text(comment.Body), width-2-len(indent))
+		for _, line := range strings.Split(body, "\n") {
+			fmt.Fprintf(w, "%s  %s\n", indent, line)
+		}
+	}
+}
+
+// scrollToSelectedComment keeps the highlighted comment visible in the details pane
+func (ui *UI) scrollToSelectedComment(v *tui.View) {
+	if !ui.commentsFocused() {
+		v.SetOrigin(0, 0)
+		return
+	}
+	_, height := v.Size()
+	for i, line := range strings.Split(v.Buffer(), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "▶ ") {
+			if i >= height {
+				v.SetOrigin(0, i-height+1)
+			} else {
+				v.SetOrigin(0, 0)
+			}
+			return
+		}
+	}
+}
+
+func (ui *UI) focusCommentList(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	if len(ui.issues[ui.selectedIssue].Comments.Nodes) == 0 {
+		return nil
+	}
+	ui.focusComments = true
+	ui.selectedComment = 0
+	_, err := g.SetCurrentView("details")
+	return err
+}
+
+func (ui *UI) blurCommentList(g *tui.Gui, v *tui.View) error {
+	ui.focusComments = false
+	_, err := g.SetCurrentView("issues")
+	return err
+}
+
+func (ui *UI) nextComment(g *tui.Gui, v *tui.View) error {
+	if !ui.commentsFocused() {
+		return nil
+	}
+	if ui.selectedComment < len(ui.commentRows(ui.issues[ui.selectedIssue]))-1 {
+		ui.selectedComment++
+	}
+	return nil
+}
+
+func (ui *UI) prevComment(g *tui.Gui, v *tui.View) error {
+	if ui.selectedComment > 0 {
+		ui.selectedComment--
+	}
+	return nil
+}
+
+// toggleThread collapses or expands the thread of the selected comment,
+// moving the cursor to the thread's root
+func (ui *UI) toggleThread(g *tui.Gui, v *tui.View) error {
+	comment, ok := ui.currentComment()
+	if !ok {
+		return nil
+	}
+	root := threadRoot(comment)
+	ui.collapsedThreads[root] = !ui.collapsedThreads[root]
+	ui.detailsGeneration++
+	for i, row := range ui.commentRows(ui.issues[ui.selectedIssue]) {
+		if row.comment.ID == root {
+			ui.selectedComment = i
+			break
+		}
+	}
+	return nil
+}
+
+// replyToComment opens the composer to reply in the selected comment's thread
+func (ui *UI) replyToComment(g *tui.Gui, v *tui.View) error {
+	comment, ok := ui.currentComment()
+	if !ok || comment.ID == "" {
+		return nil
+	}
+	ui.focusComments = false
+	ui.showComment = true
+	ui.replyToID = threadRoot(comment)
+	ui.replyToName = comment.User.Name
+	ui.commentContent = ""
+	return nil
+}
+
+func (ui *UI) copyComment(g *tui.Gui, v *tui.View) error {
+	if comment, ok := ui.currentComment(); ok {
+		return ui.copyAndNotify("comment", comment.Body)
+	}
+	return nil
+}
+
+// copyCommentLink copies the permalink URL of the selected comment
+func (ui *UI) copyCommentLink(g *tui.Gui, v *tui.View) error {
+	if comment, ok := ui.currentComment(); ok && comment.URL != "" {
+		return ui.copyAndNotify("comment link", comment.URL)
+	}
+	return nil
+}
+
+// quoteReply opens the composer prefilled with the selected comment quoted
+func (ui *UI) quoteReply(g *tui.Gui, v *tui.View) error {
+	comment, ok := ui.currentComment()
+	if !ok {
+		return nil
+	}
+	var b strings.Builder
+	if comment.URL != "" {
+		fmt.Fprintf(&b, "> **%s** [wrote](%s):\n", comment.User.Name, comment.URL)
+	} else {
+		fmt.Fprintf(&b, "> **%s** wrote:\n", comment.User.Name)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(comment.Body), "\n") {
+		fmt.Fprintf(&b, "> %s\n", line)
+	}
+	b.WriteString("\n")
+
+	ui.focusComments = false
+	ui.showComment = true
+	ui.commentContent = b.String()
+	return nil
+}
+
+// editComment opens the composer with the selected comment's body for editing
+func (ui *UI) editComment(g *tui.Gui, v *tui.View) error {
+	comment, ok := ui.currentComment()
+	if !ok || comment.ID == "" {
+		return nil
+	}
+	if comment.User.ID != ui.viewerID {
+		return nil
+	}
+	ui.focusComments = false
+	ui.showComment = true
+	ui.editingCommentID = comment.ID
+	ui.commentContent = comment.Body
+	return nil
+}
+
+// reactionChoices are the emoji offered by the reaction picker
+var reactionChoices = []string{"👍", "👎", "❤️", "🎉", "😄", "👀", "🚀"}
+
+// reactionEmoji renders a reaction, which the API may report by shortcode
+// name such as "+1", as its Unicode emoji
+func reactionEmoji(name string) string {
+	for _, r := range name {
+		if r > unicode.MaxASCII {
+			return name
+		}
+	}
+	return emoji.Render(":" + name + ":")
+}
+
+// reactionSummary renders a comment's reactions with counts, e.g. "👍 3  🎉 1"
+func (ui *UI) reactionSummary(comment api.Comment) string {
+	var order []string
+	counts := make(map[string]int)
+	for _, reaction := range comment.Reactions {
+		e := reactionEmoji(reaction.Emoji)
+		if counts[e] == 0 {
+			order = append(order, e)
+		}
+		counts[e]++
+	}
+	parts := make([]string, len(order))
+	for i, e := range order {
+		parts[i] = fmt.Sprintf("%s %d", e, counts[e])
+	}
+	return strings.Join(parts, "  ")
+}
+
+// reactToComment opens an emoji picker for the selected comment; choosing an
+// emoji the viewer already reacted with removes that reaction
+func (ui *UI) reactToComment(g *tui.Gui, v *tui.View) error {
+	comment, ok := ui.currentComment()
+	if !ok || comment.ID == "" || ui.client == nil {
+		return nil
+	}
+	mine := make(map[string]string)
+	for _, reaction := range comment.Reactions {
+		if reaction.User.ID == ui.viewerID {
+			mine[reactionEmoji(reaction.Emoji)] = reaction.ID
+		}
+	}
+	items := make([]pickerItem, len(reactionChoices))
+	for i, e := range reactionChoices {
+		items[i] = pickerItem{label: e, value: e}
+		if _, reacted := mine[e]; reacted {
+			items[i].label += " (remove)"
+		}
+	}
+	ui.openPicker("React", items, func(item pickerItem) error {
+		var err error
+		if id, reacted := mine[item.value]; reacted {
+			err = ui.client.RemoveReaction(context.Background(), id)
+		} else {
+			err = ui.client.AddReaction(context.Background(), comment.ID, item.value)
+		}
+		if err != nil {
+			ui.notifyError("Reacting", err)
+			return nil
+		}
+		if _, err := ui.reloadSelected(); err != nil {
+			ui.notifyError("Refresh", err)
+		}
+		return nil
+	})
+	return nil
+}
+
+func (ui *UI) openCommentAuthor(g *tui.Gui, v *tui.View) error {
+	if comment, ok := ui.currentComment(); ok && comment.User.URL != "" {
+		return ui.openURL(comment.User.URL)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+const (
+	// commitScanDepth is how many recent commits are scanned for identifiers
+	commitScanDepth = 300
+	// maxReferencedIssues caps the issues fetched for the commits view
+	maxReferencedIssues = 30
+)
+
+// referencePattern finds issue identifiers inside commit messages
+var referencePattern = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9]*-[0-9]+\b`)
+
+// referencedIdentifiers returns the identifiers of known teams mentioned in
+// the recent commits of the repository at dir, most recent first
+func referencedIdentifiers(dir string, teamKeys map[string]bool) ([]string, error) {
+	cmd := exec.Command("git", "log", fmt.Sprintf("-n%d", commitScanDepth), "--format=%B")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var identifiers []string
+	for _, match := range referencePattern.FindAllString(string(out), -1) {
+		identifier := strings.ToUpper(match)
+		key := identifier[:strings.LastIndexByte(identifier, '-')]
+		if !teamKeys[key] || seen[identifier] {
+			continue
+		}
+		seen[identifier] = true
+		identifiers = append(identifiers, identifier)
+	}
+	return identifiers, nil
+}
+
+// showCommitIssues lists the issues referenced in the current repository's
+// recent commits with their current states; choosing one focuses it
+func (ui *UI) showCommitIssues(g *tui.Gui, v *tui.View) error {
+	teams := ui.store.Teams()
+	if ui.client == nil || len(teams) == 0 {
+		return nil
+	}
+	teamKeys := make(map[string]bool)
+	for _, team := range teams {
+		teamKeys[strings.ToUpper(team.Key)] = true
+	}
+	dir := ""
+	if team, ok := ui.store.CurrentTeam(); ok {
+		dir = expandHome(ui.config.Repos[team.Key])
+	}
+	identifiers, err := referencedIdentifiers(dir, teamKeys)
+	if err != nil {
+		ui.notifyError("Reading git log", err)
+		return nil
+	}
+	if len(identifiers) == 0 {
+		ui.notify("No issues referenced in the last %d commits", commitScanDepth)
+		return nil
+	}
+	if len(identifiers) > maxReferencedIssues {
+		identifiers = identifiers[:maxReferencedIssues]
+	}
+
+	go func() {
+		issues, err := ui.client.GetIssuesByIdentifier(context.Background(), identifiers)
+		ui.gui.Update(func(g *tui.Gui) error {
+			if err != nil {
+				ui.notifyError("Loading referenced issues", err)
+				return nil
+			}
+			found := make(map[string]api.Issue, len(issues))
+			for _, issue := range issues {
+				found[issue.Identifier] = issue
+			}
+			var items []pickerItem
+			for _, identifier := range identifiers {
+				issue, ok := found[identifier]
+				if !ok {
+					items = append(items, pickerItem{label: identifier, disabled: true, reason: "not found"})
+					continue
+				}
+				label := fmt.Sprintf("%s %-12s %s", identifier, "["+issue.State.Name+"]", ui.text(issue.Title))
+				items = append(items, pickerItem{label: label, value: identifier})
+			}
+			ui.openPicker("Issues in recent commits", items, func(item pickerItem) error {
+				return ui.focusIssue(g, item.value)
+			})
+			return nil
+		})
+	}()
+	return nil
+}
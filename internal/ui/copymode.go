@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"lazylinear/internal/tui"
+)
+
+// copyMode suspends the UI and prints the details pane as plain text on the
+// normal screen, without frames or colors, so any part of it can be selected
+// with the terminal's own mouse selection. Enter returns to the UI.
+func (ui *UI) copyMode(g *tui.Gui, v *tui.View) error {
+	dv, err := g.View("details")
+	if err != nil {
+		return nil
+	}
+	content := strings.TrimRight(dv.Buffer(), "\n")
+
+	if err := g.Suspend(); err != nil {
+		ui.notifyError("Copy mode", err)
+		return nil
+	}
+	fmt.Print("\033[H\033[2J")
+	fmt.Println(content)
+	fmt.Print("\n-- Copy mode: select text with the mouse, press Enter to return --")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+	return g.Resume()
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"lazylinear/internal/lint"
+	"lazylinear/internal/tui"
+)
+
+// expandInstruction tells the model how to draft an issue description
+const expandInstruction = "You draft Linear issue descriptions. Given an issue title and any notes, " +
+	"write a concise markdown description with the sections Summary, Steps to reproduce, " +
+	"Expected behavior, and Actual behavior, leaving out sections that do not apply. " +
+	"Do not invent specifics; use placeholders like <version> where details are unknown. " +
+	"Reply with the description only, without the title."
+
+// newIssue opens the composer to draft a new issue in the current team
+func (ui *UI) newIssue(g *tui.Gui, v *tui.View) error {
+	team, ok := ui.store.CurrentTeam()
+	if !ok {
+		return nil
+	}
+	if ok, _ := ui.canEditIssue(team.ID); !ok {
+		return nil
+	}
+	ui.creatingIssue = true
+	ui.lintWarnings = nil
+	ui.showComment = true
+	ui.commentContent = ""
+	return nil
+}
+
+// splitDraft splits composer text into a title (first line) and description
+func splitDraft(text string) (string, string) {
+	title, description, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(description)
+}
+
+// submitIssue lints the drafted issue and creates it. Lint warnings are shown
+// in the composer title the first time; submitting again creates the issue anyway.
+func (ui *UI) submitIssue(g *tui.Gui, v *tui.View) error {
+	title, description := splitDraft(v.Buffer())
+	if title == "" {
+		return nil
+	}
+
+	if ui.lintWarnings == nil {
+		warnings := lint.Check(ui.config.IssueLint, title, description)
+		if len(warnings) > 0 {
+			ui.lintWarnings = warnings
+			return nil
+		}
+	}
+
+	if ui.client != nil {
+		team, _ := ui.store.CurrentTeam()
+		if created, err := ui.client.CreateIssue(context.Background(), team.ID, title, description); err != nil {
+			ui.notifyError("Creating issue", err)
+		} else {
+			ui.refreshIssues(g, v)
+			ui.notify("Created %s", created.Identifier)
+		}
+	}
+
+	return ui.cancelComment(g, v)
+}
+
+// expandDraft sends the drafted title and notes to the configured model and
+// replaces the notes with the structured description it writes, which can be
+// edited before the issue is created
+func (ui *UI) expandDraft(g *tui.Gui, v *tui.View) error {
+	if !ui.creatingIssue || ui.expandingDraft || v == nil {
+		return nil
+	}
+	draft := v.Buffer()
+	title, notes := splitDraft(draft)
+	if title == "" {
+		ui.notify("Type a title on the first line to expand it")
+		return nil
+	}
+	model := ui.llmClient()
+	if !model.Configured() {
+		ui.notify("Set llm.command or llm.endpoint in ~/.lazylinear/config.json to expand drafts")
+		return nil
+	}
+
+	prompt := "Title: " + title
+	if team, ok := ui.store.CurrentTeam(); ok {
+		prompt = fmt.Sprintf("Team: %s (%s)\n%s", team.Name, team.Key, prompt)
+	}
+	if notes != "" {
+		prompt += "\n\nNotes:\n" + notes
+	}
+	ui.expandingDraft = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), summarizeTimeout)
+		defer cancel()
+		description, err := model.Complete(ctx, expandInstruction, prompt)
+		ui.gui.Update(func(g *tui.Gui) error {
+			ui.expandingDraft = false
+			if err != nil {
+				ui.notifyError("Expanding draft", err)
+				return nil
+			}
+			cv, err := g.View("comment")
+			if err != nil || !ui.creatingIssue {
+				return nil
+			}
+			if cv.Buffer() != draft {
+				ui.notify("Draft changed while expanding; kept your edits")
+				return nil
+			}
+			text := title + "\n\n" + strings.TrimSpace(description)
+			cv.Clear()
+			fmt.Fprint(cv, text)
+			lines := strings.Split(text, "\n")
+			cv.SetCursor(len(lines[len(lines)-1]), len(lines)-1)
+			ui.lintWarnings = nil
+			return nil
+		})
+	}()
+	return nil
+}
+
+// composerTitle returns the title of the composer for the current mode
+func (ui *UI) composerTitle() string {
+	switch {
+	case ui.creatingIssue && len(ui.lintWarnings) > 0:
+		return "⚠ " + strings.Join(ui.lintWarnings, "; ") + " (Ctrl+S to create anyway, Esc to cancel)"
+	case ui.creatingIssue && ui.expandingDraft:
+		return "New Issue: expanding the draft…"
+	case ui.creatingIssue:
+		return "New Issue: first line is the title (Ctrl+S to create, Ctrl+E to expand, Esc to cancel)"
+	case ui.editingCommentID != "":
+		return "Edit Comment (Ctrl+S to save, Esc to cancel)"
+	case ui.replyToID != "":
+		return "Reply to " + ui.replyToName + " (Ctrl+S to submit, Esc to cancel)"
+	default:
+		return "Add Comment (Ctrl+S to submit, Esc to cancel)"
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/markdown"
+	"lazylinear/internal/tui"
+)
+
+// loadCustomerNeeds fetches an issue's customer requests in the background,
+// once per issue. Errors are not reported: workspaces without customer
+// requests enabled reject the query, and the section is simply left out.
+func (ui *UI) loadCustomerNeeds(issue api.Issue) {
+	if ui.client == nil || issue.ID == "" {
+		return
+	}
+	if _, requested := ui.customerNeeds[issue.ID]; requested {
+		return
+	}
+	ui.customerNeeds[issue.ID] = nil
+	go func() {
+		needs, err := ui.client.GetCustomerNeeds(context.Background(), issue.ID)
+		if err != nil || len(needs) == 0 {
+			return
+		}
+		ui.gui.Update(func(g *tui.Gui) error {
+			ui.customerNeeds[issue.ID] = needs
+			ui.detailsGeneration++
+			return nil
+		})
+	}()
+}
+
+// renderCustomerNeeds writes the customers requesting the issue with the
+// first line of each request
+func (ui *UI) renderCustomerNeeds(w io.Writer, issue api.Issue, width int) {
+	needs := ui.customerNeeds[issue.ID]
+	if len(needs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nCustomer requests (%d):\n", len(needs))
+	for _, need := range needs {
+		name := "Unknown customer"
+		if need.Customer != nil {
+			name = need.Customer.Name
+		}
+		line := "- " + name
+		if need.Priority == 1 {
+			line += " (important)"
+		}
+		if body := strings.TrimSpace(need.Body); body != "" {
+			line += ": " + ui.text(strings.SplitN(body, "\n", 2)[0])
+		}
+		fmt.Fprintln(w, markdown.Truncate(line, width))
+	}
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+// identifierPattern matches issue identifiers such as ENG-123
+var identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*-[0-9]+$`)
+
+// IsIdentifier reports whether s looks like an issue identifier, so that
+// `lazylinear ENG-123` can be used as a deep link into the TUI
+func IsIdentifier(s string) bool {
+	return identifierPattern.MatchString(s)
+}
+
+// FocusIssue selects the issue with the given identifier once the UI starts,
+// switching to its team and clearing filters that would hide it
+func (ui *UI) FocusIssue(identifier string) {
+	ui.gui.Update(func(g *tui.Gui) error {
+		return ui.focusIssue(g, strings.ToUpper(identifier))
+	})
+}
+
+func (ui *UI) focusIssue(g *tui.Gui, identifier string) error {
+	if ui.client == nil {
+		return nil
+	}
+	issue, err := ui.client.GetIssue(context.Background(), identifier)
+	if err != nil {
+		ui.notifyError("Opening "+identifier, err)
+		return nil
+	}
+
+	for i, team := range ui.store.Teams() {
+		if team.ID == issue.Team.ID && i != ui.store.TeamIndex() && !ui.allTeams() {
+			ui.store.SelectTeam(i)
+			if err := ui.loadTeam(g, nil); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	ui.currentView = 0
+	ui.store.SetFilter(store.Filter{View: store.AllView})
+	if !containsIssue(ui.store.All(), issue.ID) {
+		// Issues outside the listed states are shown for this session only
+		ui.store.UpsertIssue(*issue)
+	}
+
+	for i := range ui.issues {
+		if ui.issues[i].ID != issue.ID {
+			continue
+		}
+		ui.selectRow(i)
+		if lv, err := g.View("issues"); err == nil {
+			setListCursor(lv, i)
+		}
+		break
+	}
+	return nil
+}
+
+// containsIssue reports whether issues includes the issue with the given ID
+func containsIssue(issues []api.Issue, id string) bool {
+	for _, issue := range issues {
+		if issue.ID == id {
+			return true
+		}
+	}
+	return false
+}
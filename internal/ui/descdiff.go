@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/markdown"
+	"lazylinear/internal/tui"
+)
+
+// maxDiffCells bounds the line-by-line comparison; larger descriptions are
+// shown as wholly replaced
+const maxDiffCells = 1_000_000
+
+// seenDescriptionTTL is how long the description of an issue not viewed
+// again is remembered
+const seenDescriptionTTL = 90 * 24 * time.Hour
+
+// descriptionsPath is where the last viewed description of each issue is
+// kept between sessions, keyed by issue ID
+func descriptionsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lazylinear", "descriptions.json"), nil
+}
+
+// seenDescription is the last viewed description of an issue, and when the
+// issue was last viewed
+type seenDescription struct {
+	Description string    `json:"description"`
+	SeenAt      time.Time `json:"seenAt"`
+}
+
+// loadSeenDescriptions reads the descriptions recorded by earlier sessions;
+// a missing or unreadable file starts afresh
+func loadSeenDescriptions() map[string]seenDescription {
+	seen := make(map[string]seenDescription)
+	path, err := descriptionsPath()
+	if err != nil {
+		return seen
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return seen
+	}
+	if json.Unmarshal(data, &seen) == nil {
+		return seen
+	}
+	// Earlier versions kept only the descriptions
+	var descriptions map[string]string
+	if json.Unmarshal(data, &descriptions) == nil {
+		for id, description := range descriptions {
+			seen[id] = seenDescription{Description: description, SeenAt: time.Now()}
+		}
+	}
+	return seen
+}
+
+// saveSeenDescriptions records the last viewed descriptions, dropping those
+// of issues not viewed within seenDescriptionTTL
+func saveSeenDescriptions(seen map[string]seenDescription) error {
+	for id, entry := range seen {
+		if time.Since(entry.SeenAt) > seenDescriptionTTL {
+			delete(seen, id)
+		}
+	}
+	path, err := descriptionsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(seen)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of a file saved by earlier versions
+	return os.Chmod(path, 0600)
+}
+
+// noteDescription records the description of a detailed issue as viewed.
+// When it differs from the one viewed before, the earlier text is kept for
+// the rest of the session so the change can be flagged and diffed.
+func (ui *UI) noteDescription(issue api.Issue) {
+	if !issue.Detailed {
+		return
+	}
+	if ui.seenDescriptions == nil {
+		ui.seenDescriptions = loadSeenDescriptions()
+	}
+	previous, ok := ui.seenDescriptions[issue.ID]
+	// An unchanged description is saved again only to keep it from expiring
+	if ok && previous.Description == issue.Description && time.Since(previous.SeenAt) < 24*time.Hour {
+		return
+	}
+	if ok && previous.Description != issue.Description {
+		ui.descriptionChanges[issue.ID] = previous.Description
+	}
+	ui.seenDescriptions[issue.ID] = seenDescription{Description: issue.Description, SeenAt: time.Now()}
+	if err := saveSeenDescriptions(ui.seenDescriptions); err != nil {
+		ui.log.Warn("saving viewed descriptions", "err", err)
+	}
+}
+
+// renderDescription writes the description section of the details pane,
+// flagging a description changed since it was last viewed, or showing the
+// change as a diff while toggled on
+func (ui *UI) renderDescription(w io.Writer, issue api.Issue, width int) {
+	previous, changed := ui.descriptionChanges[issue.ID]
+	if !changed {
+		fmt.Fprintf(w, "\nDescription:\n%s\n", markdown.Render(ui.text(issue.Description), width))
+		return
+	}
+	if !ui.showDescriptionDiff {
+		fmt.Fprintf(w, "\nDescription: %s\n%s\n", colorize(ui.theme().dueSoon, "changed since you last viewed it (x to diff)"), markdown.Render(ui.text(issue.Description), width))
+		return
+	}
+	fmt.Fprintln(w, "\nDescription changes since you last viewed it (x to hide):")
+	for _, line := range diffLines(ui.text(previous), ui.text(issue.Description)) {
+		switch line[0] {
+		case '+':
+			line = colorize(ui.theme().added, line)
+		case '-':
+			line = colorize(ui.theme().removed, line)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// toggleDescriptionDiff switches changed descriptions between the rendered
+// text and a diff against the text last viewed
+func (ui *UI) toggleDescriptionDiff(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	if _, changed := ui.descriptionChanges[ui.issues[ui.selectedIssue].ID]; !changed && !ui.showDescriptionDiff {
+		ui.notify("Description unchanged since you last viewed it")
+		return nil
+	}
+	ui.showDescriptionDiff = !ui.showDescriptionDiff
+	return nil
+}
+
+// diffLines compares two texts line by line, prefixing kept lines with a
+// space, removed ones with "-", and added ones with "+"
+func diffLines(before, after string) []string {
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+	if len(a)*len(b) > maxDiffCells {
+		var lines []string
+		for _, line := range a {
+			lines = append(lines, "- "+line)
+		}
+		for _, line := range b {
+			lines = append(lines, "+ "+line)
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			lines = append(lines, "+ "+b[j])
+			j++
+		default:
+			lines = append(lines, "- "+a[i])
+			i++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// detailsRetryDelay is how long after a failed load an issue's details may
+// be requested again
+const detailsRetryDelay = 10 * time.Second
+
+// loadDetails fetches the fields issue lists leave out (description,
+// comments, and so on) in the background, once per listed issue, and swaps
+// the full issue into the store when it arrives
+func (ui *UI) loadDetails(issue api.Issue) {
+	if ui.client == nil || issue.ID == "" || issue.Detailed || ui.detailsRequested[issue.ID] {
+		return
+	}
+	ui.detailsRequested[issue.ID] = true
+	go func() {
+		full, err := ui.client.GetIssue(context.Background(), issue.ID)
+		ui.gui.Update(func(g *tui.Gui) error {
+			if err != nil {
+				ui.notifyError("Loading "+issue.Identifier, err)
+				// Allow a retry, but not on the redraw this error causes
+				time.AfterFunc(detailsRetryDelay, func() {
+					ui.gui.Update(func(g *tui.Gui) error {
+						delete(ui.detailsRequested, issue.ID)
+						ui.issueSelected()
+						return nil
+					})
+				})
+				return nil
+			}
+			delete(ui.detailsRequested, issue.ID)
+			// The team may have been switched while loading
+			if containsIssue(ui.store.All(), issue.ID) {
+				ui.store.UpsertIssue(*full)
+			}
+			return nil
+		})
+	}()
+}
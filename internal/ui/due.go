@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+const (
+	// dueSoonWindow is how close a due date or SLA breach is highlighted
+	dueSoonWindow = 3 * 24 * time.Hour
+	// dueDateLayout is the format of the API's date-only due dates
+	dueDateLayout = "2006-01-02"
+)
+
+// formatRemaining renders time left compactly, like "2d", "5h", or "-1d"
+// once passed
+func formatRemaining(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%s%dd", sign, int(d.Hours())/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%s%dh", sign, int(d.Hours()))
+	default:
+		return fmt.Sprintf("%s%dm", sign, int(d.Minutes()))
+	}
+}
+
+// deadlineIndicator renders a glyph and the time left until at, colored
+// as the deadline approaches or passes
+func (ui *UI) deadlineIndicator(glyph string, at time.Time) string {
+	left := time.Until(at)
+	indicator := glyph + formatRemaining(left)
+	switch {
+	case left < 0:
+		return colorize(ui.theme().overdue, indicator)
+	case left < dueSoonWindow:
+		return colorize(ui.theme().dueSoon, indicator)
+	}
+	return indicator
+}
+
+// dueIndicators renders the SLA breach (⏰) and due date (📅) indicators of
+// an issue list row, each followed by a space, or "" when neither applies.
+// Resolved issues have no deadlines left to watch.
+func (ui *UI) dueIndicators(issue api.Issue) string {
+	if issue.CompletedAt != "" || issue.CanceledAt != "" {
+		return ""
+	}
+	var s string
+	if at, ok := parseTimestamp(issue.SLABreachesAt); ok {
+		s += ui.deadlineIndicator("⏰", at) + " "
+	}
+	if issue.DueDate != "" {
+		if due, err := time.ParseInLocation(dueDateLayout, issue.DueDate, time.Local); err == nil {
+			// Due dates are due by the end of the day
+			s += ui.deadlineIndicator("📅", due.AddDate(0, 0, 1)) + " "
+		}
+	}
+	return s
+}
+
+func (ui *UI) toggleDueThisWeek(g *tui.Gui, v *tui.View) error {
+	ui.store.UpdateFilter(func(f *store.Filter) { f.DueThisWeek = !f.DueThisWeek })
+	return nil
+}
+
+func (ui *UI) toggleOverdue(g *tui.Gui, v *tui.View) error {
+	ui.store.UpdateFilter(func(f *store.Filter) { f.Overdue = !f.Overdue })
+	return nil
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/export"
+	"lazylinear/internal/tui"
+)
+
+// exportView offers to copy or save the filtered issue list as a markdown
+// table or CSV
+func (ui *UI) exportView(g *tui.Gui, v *tui.View) error {
+	if len(ui.issues) == 0 {
+		return nil
+	}
+	issues := append([]api.Issue(nil), ui.issues...)
+	base := "lazylinear-" + time.Now().Format("2006-01-02")
+	items := []pickerItem{
+		{label: "Copy as Markdown table", value: "copy-md"},
+		{label: "Copy as CSV", value: "copy-csv"},
+		{label: "Save to " + base + ".md", value: "save-md"},
+		{label: "Save to " + base + ".csv", value: "save-csv"},
+	}
+	ui.openPicker(fmt.Sprintf("Export %d issues", len(issues)), items, func(item pickerItem) error {
+		write := export.Markdown
+		ext := ".md"
+		if item.value == "copy-csv" || item.value == "save-csv" {
+			write, ext = export.CSV, ".csv"
+		}
+
+		if item.value == "copy-md" || item.value == "copy-csv" {
+			var buf bytes.Buffer
+			if err := write(&buf, issues); err != nil {
+				ui.notifyError("Exporting", err)
+				return nil
+			}
+			return ui.copyAndNotify(fmt.Sprintf("%d issues", len(issues)), buf.String())
+		}
+
+		path := base + ext
+		if err := writeFile(path, func(w io.Writer) error { return write(w, issues) }); err != nil {
+			ui.notifyError("Exporting", err)
+			return nil
+		}
+		ui.notify("Exported %d issues to %s", len(issues), path)
+		return nil
+	})
+	return nil
+}
+
+// writeFile creates path and fills it with write
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
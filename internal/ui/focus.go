@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// focusTick is how often the status bar countdown is redrawn
+const focusTick = 15 * time.Second
+
+// focusTimer is a running focus session on an issue
+type focusTimer struct {
+	issue   api.Issue
+	started time.Time
+	length  time.Duration
+	stop    chan struct{}
+}
+
+// focusLength returns the configured focus session length
+func (ui *UI) focusLength() time.Duration {
+	if ui.config.FocusMinutes > 0 {
+		return time.Duration(ui.config.FocusMinutes) * time.Minute
+	}
+	return 25 * time.Minute
+}
+
+// toggleFocus starts a focus timer on the selected issue, or stops the
+// running one, recording the time worked so far
+func (ui *UI) toggleFocus(g *tui.Gui, v *tui.View) error {
+	if t := ui.focus; t != nil {
+		close(t.stop)
+		ui.focus = nil
+		ui.trackStop()
+		worked := time.Since(t.started).Round(time.Minute)
+		if worked < time.Minute {
+			ui.notify("Focus on %s stopped", t.issue.Identifier)
+			return nil
+		}
+		ui.recordFocus(t, worked)
+		return nil
+	}
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	t := &focusTimer{issue: ui.issues[ui.selectedIssue], started: time.Now(), length: ui.focusLength(), stop: make(chan struct{})}
+	ui.focus = t
+	ui.trackStart(t.issue)
+	go ui.runFocus(t)
+	ui.notify("Focusing on %s for %s (F to stop)", t.issue.Identifier, formatWorked(t.length))
+	return nil
+}
+
+// runFocus keeps the status bar countdown current and completes the session
+// when its time is up, unless it is stopped first
+func (ui *UI) runFocus(t *focusTimer) {
+	ticker := time.NewTicker(focusTick)
+	defer ticker.Stop()
+	done := time.NewTimer(t.length)
+	defer done.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			ui.gui.Update(func(g *tui.Gui) error { return nil })
+		case <-done.C:
+			ui.gui.Update(func(g *tui.Gui) error {
+				if ui.focus != t {
+					return nil
+				}
+				ui.focus = nil
+				ui.trackStop()
+				g.Beep()
+				ui.flashUntil = time.Now().Add(flashDuration)
+				time.AfterFunc(flashDuration, func() {
+					ui.gui.Update(func(g *tui.Gui) error { return nil })
+				})
+				ui.recordFocus(t, t.length)
+				return nil
+			})
+			return
+		}
+	}
+}
+
+// focusStatus renders the running timer for the status bar, e.g.
+// "[⏱ ENG-123 18m left]"
+func (ui *UI) focusStatus() string {
+	t := ui.focus
+	if t == nil {
+		return ""
+	}
+	// Count partial minutes up, so the last minute reads "1m left"
+	left := time.Until(t.started.Add(t.length)) + time.Minute - time.Nanosecond
+	return fmt.Sprintf("[⏱ %s %s left] ", t.issue.Identifier, formatWorked(left))
+}
+
+// formatWorked renders a duration in whole minutes, e.g. "25m" or "1h30m"
+func formatWorked(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+}
+
+// recordFocus logs a finished session where focus_log says
+func (ui *UI) recordFocus(t *focusTimer, worked time.Duration) {
+	mode := ui.config.FocusLog
+	if mode == "" {
+		mode = "file"
+	}
+	if mode == "file" || mode == "both" {
+		if err := appendTimeLog(t, worked); err != nil {
+			ui.notifyError("Writing time log", err)
+			return
+		}
+	}
+	if (mode == "comment" || mode == "both") && ui.client != nil {
+		body := fmt.Sprintf("Worked %s", formatWorked(worked))
+		if err := ui.client.AddComment(context.Background(), t.issue.ID, body); err != nil {
+			ui.notifyError("Posting time comment", err)
+			return
+		}
+	}
+	ui.notify("Worked %s on %s", formatWorked(worked), t.issue.Identifier)
+}
+
+// appendTimeLog appends a session to ~/.lazylinear/timelog.csv as
+// start time, identifier, minutes worked, and title
+func appendTimeLog(t *focusTimer, worked time.Duration) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(home, ".lazylinear")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, "timelog.csv"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := csv.NewWriter(file)
+	w.Write([]string{t.started.Format(time.RFC3339), t.issue.Identifier, fmt.Sprint(int(worked.Minutes())), t.issue.Title})
+	w.Flush()
+	return w.Error()
+}
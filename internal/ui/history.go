@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// loadHistory fetches an issue's change history in the background, once per
+// issue, and redraws when it arrives
+func (ui *UI) loadHistory(issue api.Issue) {
+	if ui.client == nil || issue.ID == "" {
+		return
+	}
+	if _, requested := ui.history[issue.ID]; requested {
+		return
+	}
+	ui.history[issue.ID] = nil
+	go func() {
+		entries, err := ui.client.GetIssueHistory(context.Background(), issue.ID)
+		if err != nil {
+			ui.gui.Update(func(g *tui.Gui) error {
+				ui.notifyError("Loading history", err)
+				return nil
+			})
+			return
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].CreatedAt < entries[j].CreatedAt })
+		ui.gui.Update(func(g *tui.Gui) error {
+			ui.history[issue.ID] = entries
+			ui.detailsGeneration++
+			return nil
+		})
+	}()
+}
+
+// describeChange summarizes a history entry, or returns "" for changes the
+// timeline does not show
+func describeChange(entry api.HistoryEntry) string {
+	var changes []string
+	if entry.ToState != nil {
+		from := "?"
+		if entry.FromState != nil {
+			from = entry.FromState.Name
+		}
+		changes = append(changes, fmt.Sprintf("%s → %s", from, entry.ToState.Name))
+	}
+	switch {
+	case entry.ToAssignee != nil:
+		changes = append(changes, "assigned "+entry.ToAssignee.Name)
+	case entry.FromAssignee != nil:
+		changes = append(changes, "unassigned "+entry.FromAssignee.Name)
+	}
+	for _, label := range entry.AddedLabels {
+		changes = append(changes, "+"+label.Name)
+	}
+	for _, label := range entry.RemovedLabels {
+		changes = append(changes, "-"+label.Name)
+	}
+	return strings.Join(changes, ", ")
+}
+
+// renderHistory writes the issue's timeline of state, assignee, and label
+// changes, oldest first
+func (ui *UI) renderHistory(w io.Writer, issue api.Issue) {
+	var lines []string
+	for _, entry := range ui.history[issue.ID] {
+		change := describeChange(entry)
+		if change == "" {
+			continue
+		}
+		actor := "Linear"
+		if entry.Actor != nil {
+			actor = entry.Actor.Name
+		}
+		lines = append(lines, fmt.Sprintf("- %s  %s: %s", ui.formatTimestamp(entry.CreatedAt), actor, change))
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nHistory:")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// hookTimeout bounds how long a configured shell command may run
+const hookTimeout = 10 * time.Second
+
+// issueEnv describes an issue to hook commands through the environment
+func issueEnv(issue api.Issue) []string {
+	return []string{
+		"LAZYLINEAR_IDENTIFIER=" + issue.Identifier,
+		"LAZYLINEAR_TITLE=" + issue.Title,
+		"LAZYLINEAR_URL=" + issue.URL,
+		"LAZYLINEAR_TEAM=" + issue.Team.Key,
+	}
+}
+
+// hookQueueSize caps the hook runs waiting behind a slow one
+const hookQueueSize = 64
+
+// runHook queues a configured shell command with extra environment variables
+// and optional stdin. Hooks run one at a time in the background, in the order
+// queued, and failures are reported as a toast.
+func (ui *UI) runHook(name, command string, env []string, stdin io.Reader) {
+	if strings.TrimSpace(command) == "" {
+		return
+	}
+	ui.hookOnce.Do(func() {
+		ui.hookQueue = make(chan func(), hookQueueSize)
+		go func() {
+			for run := range ui.hookQueue {
+				run()
+			}
+		}()
+	})
+	run := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdin = stdin
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		ui.log.Debug("hook", "name", name, "command", command, "err", err)
+		if err == nil {
+			return
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		ui.gui.Update(func(g *tui.Gui) error {
+			ui.notifyError(name+" hook", err)
+			return nil
+		})
+	}
+	select {
+	case ui.hookQueue <- run:
+	default:
+		ui.log.Warn("hook queue full, skipping", "name", name)
+	}
+}
+
+// trackStart starts the external time tracker on an issue, first stopping
+// it on the issue tracked before, if any
+func (ui *UI) trackStart(issue api.Issue) {
+	if ui.tracking != nil && ui.tracking.ID == issue.ID {
+		return
+	}
+	ui.trackStop()
+	tracked := issue
+	ui.tracking = &tracked
+	ui.runHook("Time tracker start", ui.config.TimeTracker.Start, issueEnv(issue), nil)
+}
+
+// trackStop stops the external time tracker, if it is tracking an issue
+func (ui *UI) trackStop() {
+	if ui.tracking == nil {
+		return
+	}
+	issue := *ui.tracking
+	ui.tracking = nil
+	ui.runHook("Time tracker stop", ui.config.TimeTracker.Stop, issueEnv(issue), nil)
+}
+
+// issueHook runs an event hook for an issue, passing it as JSON on stdin
+func (ui *UI) issueHook(event, command string, issue api.Issue, env ...string) {
+	if strings.TrimSpace(command) == "" {
+		return
+	}
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return
+	}
+	env = append(issueEnv(issue), append(env, "LAZYLINEAR_EVENT="+event)...)
+	ui.runHook(event, command, env, bytes.NewReader(data))
+}
+
+// hookSelection runs the on_select hook when the selected issue changed
+// since the last call
+func (ui *UI) hookSelection(issue api.Issue) {
+	if issue.ID == ui.hookedSelection {
+		return
+	}
+	ui.hookedSelection = issue.ID
+	ui.issueHook("select", ui.config.Hooks.OnSelect, issue)
+}
+
+// hookStateChanges runs the on_state_change hook for every loaded issue
+// whose state differs from when it was last seen. It subscribes to the
+// store, so local and remote changes alike are caught.
+func (ui *UI) hookStateChanges() {
+	for _, issue := range ui.store.All() {
+		previous, seen := ui.hookedStates[issue.ID]
+		ui.hookedStates[issue.ID] = issue.State.Name
+		if seen && previous != issue.State.Name {
+			ui.issueHook("state_change", ui.config.Hooks.OnStateChange, issue, "LAZYLINEAR_PREVIOUS_STATE="+previous)
+		}
+	}
+}
+
+// hookRefresh runs the on_refresh hook with the reloaded issues
+func (ui *UI) hookRefresh() {
+	command := ui.config.Hooks.OnRefresh
+	if strings.TrimSpace(command) == "" {
+		return
+	}
+	data, err := json.Marshal(ui.store.All())
+	if err != nil {
+		return
+	}
+	ui.runHook("refresh", command, []string{"LAZYLINEAR_EVENT=refresh"}, bytes.NewReader(data))
+}
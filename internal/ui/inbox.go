@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// inboxCategory groups notifications by how directly they concern the viewer
+type inboxCategory struct {
+	name  string
+	types []string
+}
+
+// inboxCategories are listed in priority order; notifications whose type
+// matches no category fall into the final catch-all
+var inboxCategories = []inboxCategory{
+	{"Mentions", []string{"issueMention", "issueCommentMention", "issueDescriptionMention"}},
+	{"Assigned to me", []string{"issueAssignedToYou", "issueUnassignedFromYou"}},
+	{"Replies & reactions", []string{"issueCommentReaction", "issueEmojiReaction", "issueNewComment", "issueThreadReply"}},
+	{"Subscriptions", nil},
+}
+
+// inboxRow is a rendered line in the inbox: either a category header or a notification
+type inboxRow struct {
+	category     int
+	notification *api.Notification
+}
+
+// categorize returns the inbox category index for a notification type
+func categorize(notificationType string) int {
+	for i, category := range inboxCategories {
+		for _, t := range category.types {
+			if t == notificationType {
+				return i
+			}
+		}
+	}
+	return len(inboxCategories) - 1
+}
+
+// inboxRows orders notifications by category, then unread first, then newest
+// first, omitting the members of collapsed categories
+func (ui *UI) inboxRows() []inboxRow {
+	grouped := make([][]api.Notification, len(inboxCategories))
+	for _, n := range ui.notifications {
+		i := categorize(n.Type)
+		grouped[i] = append(grouped[i], n)
+	}
+
+	var rows []inboxRow
+	for i, notifications := range grouped {
+		if len(notifications) == 0 {
+			continue
+		}
+		sort.SliceStable(notifications, func(a, b int) bool {
+			unreadA, unreadB := notifications[a].ReadAt == "", notifications[b].ReadAt == ""
+			if unreadA != unreadB {
+				return unreadA
+			}
+			return notifications[a].CreatedAt > notifications[b].CreatedAt
+		})
+		rows = append(rows, inboxRow{category: i})
+		if ui.collapsedInbox[i] {
+			continue
+		}
+		for j := range notifications {
+			rows = append(rows, inboxRow{category: i, notification: &notifications[j]})
+		}
+	}
+	return rows
+}
+
+// layoutInbox draws the inbox overlay
+func (ui *UI) layoutInbox(g *tui.Gui, maxX, maxY int) error {
+	if !ui.showInbox {
+		g.DeleteView("inbox")
+		return nil
+	}
+
+	v, err := g.SetView("inbox", 4, 2, maxX-5, maxY-3)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Inbox (Enter to open or collapse, Esc to close)"
+		v.Highlight = true
+	}
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
+
+	v.Clear()
+	rows := ui.inboxRows()
+	counts := make(map[int]int)
+	for _, n := range ui.notifications {
+		counts[categorize(n.Type)]++
+	}
+	for _, row := range rows {
+		if row.notification == nil {
+			marker := "▾"
+			if ui.collapsedInbox[row.category] {
+				marker = "▸"
+			}
+			fmt.Fprintf(v, "%s %s (%d)\n", marker, inboxCategories[row.category].name, counts[row.category])
+			continue
+		}
+		n := row.notification
+		unread := " "
+		if n.ReadAt == "" {
+			unread = "•"
+		}
+		kind := strings.TrimPrefix(n.Type, "issue")
+		fmt.Fprintf(v, "  %s %s %s: %s (%s, %s)\n", unread, colorize(ui.theme().identifier, n.Issue.Identifier), kind, ui.text(n.Issue.Title), n.Actor.Name, ui.formatTimestamp(n.CreatedAt))
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(v, "Inbox is empty")
+	}
+
+	if ui.selectedInbox >= len(rows) {
+		ui.selectedInbox = len(rows) - 1
+	}
+	if ui.selectedInbox < 0 {
+		ui.selectedInbox = 0
+	}
+	_, height := v.Size()
+	oy := 0
+	if ui.selectedInbox >= height {
+		oy = ui.selectedInbox - height + 1
+	}
+	v.SetOrigin(0, oy)
+	v.SetCursor(0, ui.selectedInbox-oy)
+	g.SetCurrentView("inbox")
+	return nil
+}
+
+func (ui *UI) setInboxKeybindings(g *tui.Gui) error {
+	if err := ui.bind(g, "issues", 'i', "inbox", ui.openInbox); err != nil {
+		return err
+	}
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "navigate", ui.nextInbox},
+		{tui.KeyArrowDown, "navigate", ui.nextInbox},
+		{'k', "navigate", ui.prevInbox},
+		{tui.KeyArrowUp, "navigate", ui.prevInbox},
+		{tui.KeyEnter, "open", ui.activateInbox},
+		{tui.KeySpace, "open", ui.activateInbox},
+		{tui.KeyEsc, "close", ui.closeInbox},
+		{'q', "close", ui.closeInbox},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "inbox", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ui *UI) openInbox(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil {
+		return nil
+	}
+	notifications, err := ui.client.GetNotifications(context.Background())
+	if err != nil {
+		ui.notifyError("Loading inbox", err)
+		return nil
+	}
+	ui.notifications = notifications
+	ui.selectedInbox = 0
+	ui.showInbox = true
+	return nil
+}
+
+func (ui *UI) closeInbox(g *tui.Gui, v *tui.View) error {
+	ui.showInbox = false
+	g.DeleteView("inbox")
+	_, err := g.SetCurrentView("issues")
+	return err
+}
+
+func (ui *UI) nextInbox(g *tui.Gui, v *tui.View) error {
+	if ui.selectedInbox < len(ui.inboxRows())-1 {
+		ui.selectedInbox++
+	}
+	return nil
+}
+
+func (ui *UI) prevInbox(g *tui.Gui, v *tui.View) error {
+	if ui.selectedInbox > 0 {
+		ui.selectedInbox--
+	}
+	return nil
+}
+
+// activateInbox toggles a category header, or jumps to the notification's
+// issue in the list (opening it in the browser when it is not loaded)
+func (ui *UI) activateInbox(g *tui.Gui, v *tui.View) error {
+	rows := ui.inboxRows()
+	if ui.selectedInbox < 0 || ui.selectedInbox >= len(rows) {
+		return nil
+	}
+	row := rows[ui.selectedInbox]
+	if row.notification == nil {
+		ui.collapsedInbox[row.category] = !ui.collapsedInbox[row.category]
+		return nil
+	}
+
+	for i, issue := range ui.issues {
+		if issue.ID == row.notification.Issue.ID {
+			ui.selectRow(i)
+			if lv, err := g.View("issues"); err == nil {
+				setListCursor(lv, i)
+			}
+			return ui.closeInbox(g, v)
+		}
+	}
+	if row.notification.Issue.URL != "" {
+		return ui.openURL(row.notification.Issue.URL)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"lazylinear/internal/tui"
+)
+
+// keyHint is a keybinding advertised in the status bar
+type keyHint struct {
+	view string
+	key  string
+	desc string
+}
+
+// keyNames spells out the special keys shown in the status bar
+var keyNames = map[tui.Key]string{
+	tui.KeyArrowUp:    "↑",
+	tui.KeyArrowDown:  "↓",
+	tui.KeyEnter:      "Enter",
+	tui.KeyEsc:        "Esc",
+	tui.KeyTab:        "Tab",
+	tui.KeySpace:      "Space",
+	tui.KeyBackspace:  "Bksp",
+	tui.KeyBackspace2: "Bksp",
+	tui.KeyCtrlC:      "Ctrl+C",
+	tui.KeyCtrlE:      "Ctrl+E",
+	tui.KeyCtrlQ:      "Ctrl+Q",
+	tui.KeyCtrlS:      "Ctrl+S",
+}
+
+// keyName returns the status bar label of a rune or tui.Key
+func keyName(key interface{}) string {
+	switch k := key.(type) {
+	case rune:
+		return string(k)
+	case tui.Key:
+		if name, ok := keyNames[k]; ok {
+			return name
+		}
+	}
+	return fmt.Sprint(key)
+}
+
+// bind registers a keybinding and records it in the keybinding registry.
+// Bindings with a description are listed in the status bar while their
+// view has focus; global bindings (view "") are listed everywhere. Every
+// press is logged at debug level.
+func (ui *UI) bind(g *tui.Gui, view string, key interface{}, desc string, handler func(*tui.Gui, *tui.View) error) error {
+	logged := func(g *tui.Gui, v *tui.View) error {
+		ui.log.Debug("key", "view", view, "key", keyName(key), "action", desc)
+		return handler(g, v)
+	}
+	if err := g.SetKeybinding(view, key, tui.ModNone, logged); err != nil {
+		return err
+	}
+	if desc != "" {
+		ui.hints = append(ui.hints, keyHint{view: view, key: keyName(key), desc: desc})
+	}
+	return nil
+}
+
+// statusHints renders the registered shortcuts of view followed by the
+// global ones, joining keys that share a description, e.g. "j/k/↓/↑: navigate"
+func (ui *UI) statusHints(view string) string {
+	var order []string
+	keys := make(map[string][]string)
+	for _, scope := range []string{view, ""} {
+		for _, hint := range ui.hints {
+			if hint.view != scope {
+				continue
+			}
+			id := scope + "\x00" + hint.desc
+			if _, ok := keys[id]; !ok {
+				order = append(order, id)
+			}
+			keys[id] = append(keys[id], hint.key)
+		}
+	}
+	parts := make([]string, len(order))
+	for i, id := range order {
+		desc := id[strings.IndexByte(id, 0)+1:]
+		// Letter keys read better before arrows and named keys
+		sort.SliceStable(keys[id], func(a, b int) bool { return len(keys[id][a]) == 1 && len(keys[id][b]) > 1 })
+		parts[i] = strings.Join(keys[id], "/") + ": " + desc
+	}
+	return strings.Join(parts, " | ")
+}
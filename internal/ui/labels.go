@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+// labelCounts counts the loaded issues carrying each label, keyed by the
+// lowercased label name
+func (ui *UI) labelCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range ui.store.All() {
+		for _, label := range issue.Labels.Nodes {
+			counts[strings.ToLower(label.Name)]++
+		}
+	}
+	return counts
+}
+
+// layoutLabels draws the labels browser overlay. Its first row clears the
+// label filter; each following row is one of the team's labels.
+func (ui *UI) layoutLabels(g *tui.Gui, maxX, maxY int) error {
+	if !ui.showLabels {
+		g.DeleteView("labels")
+		return nil
+	}
+
+	v, err := g.SetView("labels", 4, 2, maxX-5, maxY-3)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Labels (Enter to filter, n new, r rename, Esc to close)"
+		v.Highlight = true
+	}
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
+
+	v.Clear()
+	fmt.Fprintf(v, "  All labels (%d)\n", len(ui.store.All()))
+	counts := ui.labelCounts()
+	active := ui.store.Filter().Label
+	for _, label := range ui.labels {
+		swatch, _ := ansiColor(label.Color)
+		marker := " "
+		if strings.EqualFold(label.Name, active) {
+			marker = "•"
+		}
+		fmt.Fprintf(v, "%s %s %s (%d)\n", marker, colorize(swatch, "●"), ui.text(label.Name), counts[strings.ToLower(label.Name)])
+	}
+
+	if ui.selectedLabel > len(ui.labels) {
+		ui.selectedLabel = len(ui.labels)
+	}
+	if ui.selectedLabel < 0 {
+		ui.selectedLabel = 0
+	}
+	_, height := v.Size()
+	oy := 0
+	if ui.selectedLabel >= height {
+		oy = ui.selectedLabel - height + 1
+	}
+	v.SetOrigin(0, oy)
+	v.SetCursor(0, ui.selectedLabel-oy)
+	g.SetCurrentView("labels")
+	return nil
+}
+
+func (ui *UI) setLabelsKeybindings(g *tui.Gui) error {
+	if err := ui.bind(g, "issues", 'l', "labels", ui.openLabels); err != nil {
+		return err
+	}
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "navigate", ui.nextLabel},
+		{tui.KeyArrowDown, "navigate", ui.nextLabel},
+		{'k', "navigate", ui.prevLabel},
+		{tui.KeyArrowUp, "navigate", ui.prevLabel},
+		{tui.KeyEnter, "filter", ui.filterByLabel},
+		{'n', "new", ui.createLabel},
+		{'r', "rename", ui.renameLabel},
+		{tui.KeyEsc, "close", ui.closeLabels},
+		{'q', "close", ui.closeLabels},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "labels", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ui *UI) openLabels(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil {
+		return nil
+	}
+	team, ok := ui.store.CurrentTeam()
+	if !ok {
+		return nil
+	}
+	labels, err := ui.client.GetTeamLabels(context.Background(), team.ID)
+	if err != nil {
+		ui.notifyError("Loading labels", err)
+		return nil
+	}
+	ui.labels = labels
+	ui.selectedLabel = 0
+	ui.showLabels = true
+	return nil
+}
+
+func (ui *UI) closeLabels(g *tui.Gui, v *tui.View) error {
+	ui.showLabels = false
+	g.DeleteView("labels")
+	_, err := g.SetCurrentView("issues")
+	return err
+}
+
+func (ui *UI) nextLabel(g *tui.Gui, v *tui.View) error {
+	if ui.selectedLabel < len(ui.labels) {
+		ui.selectedLabel++
+	}
+	return nil
+}
+
+func (ui *UI) prevLabel(g *tui.Gui, v *tui.View) error {
+	if ui.selectedLabel > 0 {
+		ui.selectedLabel--
+	}
+	return nil
+}
+
+// filterByLabel lists only the issues carrying the selected label, or
+// clears the label filter from the "All labels" row
+func (ui *UI) filterByLabel(g *tui.Gui, v *tui.View) error {
+	name := ""
+	if ui.selectedLabel > 0 && ui.selectedLabel <= len(ui.labels) {
+		name = ui.labels[ui.selectedLabel-1].Name
+	}
+	ui.store.UpdateFilter(func(f *store.Filter) { f.Label = name })
+	ui.selectRow(0)
+	if lv, err := g.View("issues"); err == nil {
+		setListCursor(lv, 0)
+	}
+	return ui.closeLabels(g, v)
+}
+
+// createLabel prompts for a name and adds the label to the current team
+func (ui *UI) createLabel(g *tui.Gui, v *tui.View) error {
+	team, ok := ui.store.CurrentTeam()
+	if !ok {
+		return nil
+	}
+	ui.openPrompt("New label in "+team.Name, "", func(name string) error {
+		label, err := ui.client.CreateLabel(context.Background(), team.ID, name)
+		if err != nil {
+			ui.notifyError("Creating label", err)
+			return nil
+		}
+		ui.labels = append(ui.labels, *label)
+		ui.selectedLabel = len(ui.labels)
+		ui.notify("Created label %s", label.Name)
+		return nil
+	})
+	return nil
+}
+
+// renameLabel prompts for a new name for the selected label. Loaded issues
+// and an active filter on the label follow the new name.
+func (ui *UI) renameLabel(g *tui.Gui, v *tui.View) error {
+	if ui.selectedLabel < 1 || ui.selectedLabel > len(ui.labels) {
+		return nil
+	}
+	label := ui.labels[ui.selectedLabel-1]
+	ui.openPrompt("Rename label", label.Name, func(name string) error {
+		if name == label.Name {
+			return nil
+		}
+		if err := ui.client.RenameLabel(context.Background(), label.ID, name); err != nil {
+			ui.notifyError("Renaming label", err)
+			return nil
+		}
+		for i := range ui.labels {
+			if ui.labels[i].ID == label.ID {
+				ui.labels[i].Name = name
+			}
+		}
+		for _, issue := range ui.store.All() {
+			nodes := append(issue.Labels.Nodes[:0:0], issue.Labels.Nodes...)
+			renamed := false
+			for i := range nodes {
+				if nodes[i].Name == label.Name {
+					nodes[i].Name = name
+					renamed = true
+				}
+			}
+			if renamed {
+				issue.Labels.Nodes = nodes
+				ui.store.UpsertIssue(issue)
+			}
+		}
+		if strings.EqualFold(ui.store.Filter().Label, label.Name) {
+			ui.store.UpdateFilter(func(f *store.Filter) { f.Label = name })
+		}
+		ui.notify("Renamed label to %s", name)
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// loadStateSince fetches when the issue entered its current state in the
+// background, once per issue, and redraws when it arrives
+func (ui *UI) loadStateSince(issue api.Issue) {
+	if ui.client == nil || issue.ID == "" {
+		return
+	}
+	if _, requested := ui.stateSince[issue.ID]; requested {
+		return
+	}
+	ui.stateSince[issue.ID] = time.Time{}
+	go func() {
+		entered, err := ui.client.GetStateEnteredAt(context.Background(), issue.ID)
+		if err != nil {
+			ui.gui.Update(func(g *tui.Gui) error {
+				ui.notifyError("Loading state history", err)
+				return nil
+			})
+			return
+		}
+		ui.gui.Update(func(g *tui.Gui) error {
+			ui.stateSince[issue.ID] = entered
+			ui.detailsGeneration++
+			return nil
+		})
+	}()
+}
+
+// renderLifecycle writes the issue's lifecycle timestamps and how long it has
+// been in its current state
+func (ui *UI) renderLifecycle(w io.Writer, issue api.Issue) {
+	timestamps := []struct {
+		label string
+		value string
+	}{
+		{"Created", issue.CreatedAt},
+		{"Started", issue.StartedAt},
+		{"Completed", issue.CompletedAt},
+		{"Canceled", issue.CanceledAt},
+	}
+	for _, ts := range timestamps {
+		if at, ok := parseTimestamp(ts.value); ok {
+			fmt.Fprintf(w, "%s: %s (%s ago)\n", ts.label, at.Local().Format("2006-01-02 15:04"), formatAge(time.Since(at)))
+		}
+	}
+	if entered := ui.stateSince[issue.ID]; !entered.IsZero() {
+		fmt.Fprintf(w, "In %s for: %s\n", issue.State.Name, formatAge(time.Since(entered)))
+	}
+}
+
+// relativeCutoff is the age beyond which timestamps are shown as dates
+const relativeCutoff = 7 * 24 * time.Hour
+
+// defaultDateFormat is the absolute timestamp layout when none is configured
+const defaultDateFormat = "2006-01-02"
+
+// dateFormat returns the configured absolute timestamp layout
+func (ui *UI) dateFormat() string {
+	if ui.config.DateFormat == "" {
+		return defaultDateFormat
+	}
+	return ui.config.DateFormat
+}
+
+// formatTimestamp renders an API timestamp relative to now, like "3h ago",
+// or as an absolute date once it is older than a week. Unparseable values
+// are returned unchanged.
+func (ui *UI) formatTimestamp(value string) string {
+	at, ok := parseTimestamp(value)
+	if !ok {
+		return value
+	}
+	d := time.Since(at)
+	switch {
+	case d < 0 || d >= relativeCutoff:
+		return at.Local().Format(ui.dateFormat())
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours())/24)
+	}
+}
+
+// parseTimestamp parses an API timestamp, reporting false for empty values
+func parseTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	return at, err == nil
+}
+
+// formatAge renders a duration at day, hour, or minute granularity
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		days := int(d.Hours()) / 24
+		return fmt.Sprintf("%dd %dh", days, int(d.Hours())-days*24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// urlPattern finds web links in descriptions and comments, leaving out
+// closing punctuation of the surrounding markdown or prose
+var urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+[^\s<>()\[\]"'.,;:!?` + "`" + `]`)
+
+// issueLink is a URL or an issue identifier found in an issue's text
+type issueLink struct {
+	url        string
+	identifier string
+}
+
+// issueLinks lists the URLs and the identifiers of known teams' issues
+// mentioned in the issue's description and comments, in order of first
+// appearance
+func (ui *UI) issueLinks(issue api.Issue) []issueLink {
+	texts := []string{issue.Description}
+	for _, comment := range issue.Comments.Nodes {
+		texts = append(texts, comment.Body)
+	}
+	teamKeys := make(map[string]bool)
+	for _, team := range ui.store.Teams() {
+		teamKeys[strings.ToUpper(team.Key)] = true
+	}
+
+	seen := map[string]bool{issue.Identifier: true}
+	var links []issueLink
+	for _, text := range texts {
+		for _, url := range urlPattern.FindAllString(text, -1) {
+			if !seen[url] {
+				seen[url] = true
+				links = append(links, issueLink{url: url})
+			}
+		}
+		// Identifiers inside URLs are already covered by their URL
+		for _, match := range referencePattern.FindAllString(urlPattern.ReplaceAllString(text, " "), -1) {
+			identifier := strings.ToUpper(match)
+			key := identifier[:strings.LastIndexByte(identifier, '-')]
+			if teamKeys[key] && !seen[identifier] {
+				seen[identifier] = true
+				links = append(links, issueLink{identifier: identifier})
+			}
+		}
+	}
+	return links
+}
+
+// linkLabel describes a link, naming referenced issues that are loaded
+func (ui *UI) linkLabel(link issueLink) string {
+	if link.url != "" {
+		return link.url
+	}
+	for _, issue := range ui.store.All() {
+		if issue.Identifier == link.identifier {
+			return link.identifier + " " + ui.text(issue.Title)
+		}
+	}
+	return link.identifier
+}
+
+// renderLinks writes the numbered links section of the details pane
+func (ui *UI) renderLinks(w io.Writer, issue api.Issue) {
+	links := ui.issueLinks(issue)
+	if len(links) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nLinks (f, then the number and Enter, to open):")
+	for i, link := range links {
+		fmt.Fprintf(w, "  %d. %s\n", i+1, ui.linkLabel(link))
+	}
+}
+
+// openLink picks one of the selected issue's links by number. URLs open in
+// the browser; issue references jump to the issue, as relations do.
+func (ui *UI) openLink(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	from := ui.issues[ui.selectedIssue]
+	links := ui.issueLinks(from)
+	if len(links) == 0 {
+		ui.notify("%s has no links", from.Identifier)
+		return nil
+	}
+	items := make([]pickerItem, len(links))
+	for i, link := range links {
+		items[i] = pickerItem{label: fmt.Sprintf("%d %s", i+1, ui.linkLabel(link)), value: strconv.Itoa(i)}
+	}
+	ui.openFilterPicker("Open link", items, func(item pickerItem) error {
+		i, err := strconv.Atoi(item.value)
+		if err != nil || i >= len(links) {
+			return nil
+		}
+		if links[i].url != "" {
+			return ui.openURL(links[i].url)
+		}
+		ui.pushTrail(from.Identifier)
+		return ui.jumpToIssue(g, links[i].identifier)
+	})
+	return nil
+}
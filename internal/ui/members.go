@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+// workload is a member's share of the loaded team's unresolved issues; a
+// zero user stands for the unassigned issues
+type workload struct {
+	user   api.User
+	active int
+	points float64
+}
+
+// buildWorkloads totals the unresolved issues per assignee. Every active
+// member is listed, busiest first, followed by the unassigned issues.
+func buildWorkloads(members []api.User, issues []api.Issue) []workload {
+	byID := make(map[string]*workload)
+	var workloads []*workload
+	for _, member := range members {
+		if !member.Active {
+			continue
+		}
+		w := &workload{user: member}
+		byID[member.ID] = w
+		workloads = append(workloads, w)
+	}
+	unassigned := &workload{}
+	for _, issue := range issues {
+		if issue.CompletedAt != "" || issue.CanceledAt != "" {
+			continue
+		}
+		w := unassigned
+		if issue.Assignee.ID != "" {
+			w = byID[issue.Assignee.ID]
+			if w == nil {
+				// Assigned to someone outside the team, or deactivated
+				w = &workload{user: api.User{ID: issue.Assignee.ID, Name: issue.Assignee.Name}}
+				byID[issue.Assignee.ID] = w
+				workloads = append(workloads, w)
+			}
+		}
+		w.active++
+		if issue.Estimate != nil {
+			w.points += *issue.Estimate
+		}
+	}
+	sort.SliceStable(workloads, func(i, j int) bool {
+		if workloads[i].active != workloads[j].active {
+			return workloads[i].active > workloads[j].active
+		}
+		return workloads[i].user.Name < workloads[j].user.Name
+	})
+
+	result := make([]workload, 0, len(workloads)+1)
+	for _, w := range workloads {
+		result = append(result, *w)
+	}
+	return append(result, *unassigned)
+}
+
+// assigneeName names a Filter.Assignee value for the list title
+func (ui *UI) assigneeName(assignee string) string {
+	if assignee == store.Unassigned {
+		return "Unassigned"
+	}
+	for _, w := range ui.workloads {
+		if w.user.ID == assignee {
+			return w.user.Name
+		}
+	}
+	for _, issue := range ui.store.All() {
+		if issue.Assignee.ID == assignee {
+			return issue.Assignee.Name
+		}
+	}
+	return "someone"
+}
+
+// layoutMembers draws the team members overlay. Its first row clears the
+// assignee filter; each following row is a member's workload.
+func (ui *UI) layoutMembers(g *tui.Gui, maxX, maxY int) error {
+	if !ui.showMembers {
+		g.DeleteView("members")
+		return nil
+	}
+
+	v, err := g.SetView("members", 4, 2, maxX-5, maxY-3)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Team members (Enter to list their issues, Esc to close)"
+		v.Highlight = true
+	}
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
+
+	v.Clear()
+	fmt.Fprintln(v, "  Everyone")
+	active := ui.store.Filter().Assignee
+	for _, w := range ui.workloads {
+		name, id := w.user.Name, w.user.ID
+		if id == "" {
+			name, id = "Unassigned", store.Unassigned
+		}
+		marker := " "
+		if id == active {
+			marker = "•"
+		}
+		fmt.Fprintf(v, "%s %s %s · %s\n", marker, fit(ui.text(name), 24), plural(w.active, "active issue"), pluralFloat(w.points, "point"))
+	}
+
+	if ui.selectedMember > len(ui.workloads) {
+		ui.selectedMember = len(ui.workloads)
+	}
+	if ui.selectedMember < 0 {
+		ui.selectedMember = 0
+	}
+	_, height := v.Size()
+	oy := 0
+	if ui.selectedMember >= height {
+		oy = ui.selectedMember - height + 1
+	}
+	v.SetOrigin(0, oy)
+	v.SetCursor(0, ui.selectedMember-oy)
+	g.SetCurrentView("members")
+	return nil
+}
+
+func (ui *UI) setMembersKeybindings(g *tui.Gui) error {
+	if err := ui.bind(g, "issues", 'M', "members", ui.openMembers); err != nil {
+		return err
+	}
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "navigate", ui.nextMember},
+		{tui.KeyArrowDown, "navigate", ui.nextMember},
+		{'k', "navigate", ui.prevMember},
+		{tui.KeyArrowUp, "navigate", ui.prevMember},
+		{tui.KeyEnter, "list issues", ui.filterByMember},
+		{tui.KeyEsc, "close", ui.closeMembers},
+		{'q', "close", ui.closeMembers},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "members", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ui *UI) openMembers(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil {
+		return nil
+	}
+	team, ok := ui.store.CurrentTeam()
+	if !ok {
+		return nil
+	}
+	members, err := ui.teamMembers(team.ID)
+	if err != nil {
+		ui.notifyError("Loading team members", err)
+		return nil
+	}
+	ui.workloads = buildWorkloads(members, ui.store.All())
+	ui.selectedMember = 0
+	ui.showMembers = true
+	return nil
+}
+
+func (ui *UI) closeMembers(g *tui.Gui, v *tui.View) error {
+	ui.showMembers = false
+	g.DeleteView("members")
+	_, err := g.SetCurrentView("issues")
+	return err
+}
+
+func (ui *UI) nextMember(g *tui.Gui, v *tui.View) error {
+	if ui.selectedMember < len(ui.workloads) {
+		ui.selectedMember++
+	}
+	return nil
+}
+
+func (ui *UI) prevMember(g *tui.Gui, v *tui.View) error {
+	if ui.selectedMember > 0 {
+		ui.selectedMember--
+	}
+	return nil
+}
+
+// filterByMember lists only the selected member's issues, or clears the
+// assignee filter from the "Everyone" row
+func (ui *UI) filterByMember(g *tui.Gui, v *tui.View) error {
+	assignee := ""
+	if ui.selectedMember > 0 && ui.selectedMember <= len(ui.workloads) {
+		assignee = ui.workloads[ui.selectedMember-1].user.ID
+		if assignee == "" {
+			assignee = store.Unassigned
+		}
+	}
+	ui.store.UpdateFilter(func(f *store.Filter) { f.Assignee = assignee })
+	ui.selectRow(0)
+	if lv, err := g.View("issues"); err == nil {
+		setListCursor(lv, 0)
+	}
+	return ui.closeMembers(g, v)
+}
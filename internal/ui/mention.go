@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// maxMentionSuggestions caps the rows in the mention autocomplete
+const maxMentionSuggestions = 6
+
+// mention is the @mention autocomplete open in the comment composer
+type mention struct {
+	query    string
+	selected int
+	members  []api.User
+}
+
+// suggestions returns the active members whose name or display name
+// contains the query
+func (m *mention) suggestions() []api.User {
+	query := strings.ToLower(m.query)
+	var matches []api.User
+	for _, member := range m.members {
+		if !member.Active {
+			continue
+		}
+		if strings.Contains(strings.ToLower(member.Name), query) || strings.Contains(strings.ToLower(member.DisplayName), query) {
+			matches = append(matches, member)
+			if len(matches) == maxMentionSuggestions {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// mentionMarkup is the text inserted for a mention. Linear turns profile
+// URLs into mentions that notify the user.
+func mentionMarkup(user api.User) string {
+	if user.URL != "" {
+		return user.URL + " "
+	}
+	return "@" + user.DisplayName + " "
+}
+
+// composerTeamID returns the team whose members can be mentioned
+func (ui *UI) composerTeamID() string {
+	if !ui.creatingIssue && ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+		return ui.issues[ui.selectedIssue].Team.ID
+	}
+	if team, ok := ui.store.CurrentTeam(); ok {
+		return team.ID
+	}
+	return ""
+}
+
+// editMention handles a key press while composing, driving the mention
+// autocomplete. It reports whether the key was consumed.
+func (ui *UI) editMention(v *tui.View, key tui.Key, ch rune) bool {
+	m := ui.mention
+	if m == nil {
+		if ch == '@' && ui.client != nil {
+			if teamID := ui.composerTeamID(); teamID != "" {
+				if members, err := ui.teamMembers(teamID); err == nil {
+					ui.mention = &mention{members: members}
+				}
+			}
+		}
+		return false
+	}
+
+	switch {
+	case key == tui.KeyArrowDown:
+		if m.selected < len(m.suggestions())-1 {
+			m.selected++
+		}
+		return true
+	case key == tui.KeyArrowUp:
+		if m.selected > 0 {
+			m.selected--
+		}
+		return true
+	case key == tui.KeyTab || key == tui.KeyEnter:
+		suggestions := m.suggestions()
+		if m.selected >= len(suggestions) {
+			ui.mention = nil
+			return false
+		}
+		for range []rune("@" + m.query) {
+			v.EditDelete(true)
+		}
+		for _, r := range mentionMarkup(suggestions[m.selected]) {
+			v.EditWrite(r)
+		}
+		ui.mention = nil
+		return true
+	case key == tui.KeyBackspace || key == tui.KeyBackspace2:
+		if m.query == "" {
+			ui.mention = nil
+		} else {
+			r := []rune(m.query)
+			m.query = string(r[:len(r)-1])
+			m.selected = 0
+		}
+	case ch != 0 && (unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '.' || ch == '-' || ch == '_'):
+		m.query += string(ch)
+		m.selected = 0
+	default:
+		ui.mention = nil
+	}
+	return false
+}
+
+// layoutMentions draws the mention suggestions below the composer
+func (ui *UI) layoutMentions(g *tui.Gui, x, y, width int) error {
+	if ui.mention == nil {
+		g.DeleteView("mentions")
+		return nil
+	}
+	suggestions := ui.mention.suggestions()
+	height := len(suggestions) + 1
+	if height < 2 {
+		height = 2
+	}
+	v, err := g.SetView("mentions", x, y, x+width, y+height)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Highlight = true
+	}
+	v.Title = "@" + ui.mention.query + " (Tab to insert, Esc to close)"
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
+	v.Clear()
+	for _, user := range suggestions {
+		fmt.Fprintf(v, "%s (%s)\n", user.Name, user.DisplayName)
+	}
+	if len(suggestions) == 0 {
+		fmt.Fprintln(v, "No matching team members")
+	}
+	v.SetCursor(0, ui.mention.selected)
+	return nil
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/markdown"
+	"lazylinear/internal/tui"
+)
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// notePath is where the local-only notes of an issue are kept, e.g.
+// ~/.lazylinear/notes/ENG-123.md
+func notePath(identifier string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lazylinear", "notes", identifier+".md"), nil
+}
+
+// note returns the issue's notes, reading them from disk once per session
+// and after each edit
+func (ui *UI) note(issue api.Issue) string {
+	if text, ok := ui.notes[issue.Identifier]; ok {
+		return text
+	}
+	text := ""
+	if path, err := notePath(issue.Identifier); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			text = string(data)
+		}
+	}
+	ui.notes[issue.Identifier] = text
+	return text
+}
+
+// renderNote writes the notes section of the details pane
+func (ui *UI) renderNote(w io.Writer, issue api.Issue, width int) {
+	text := strings.TrimSpace(ui.note(issue))
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(w, "\nMy notes (local only, N to edit):\n%s\n", markdown.Render(ui.text(text), width))
+}
+
+// editNote suspends the UI and opens the selected issue's notes in
+// $VISUAL or $EDITOR; notes left empty are removed
+func (ui *UI) editNote(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	path, err := notePath(issue.Identifier)
+	if err != nil {
+		ui.notifyError("Editing notes", err)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		ui.notifyError("Editing notes", err)
+		return nil
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+	// The shell splits editors configured with arguments, e.g. "code -w"
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := g.Suspend(); err != nil {
+		ui.notifyError("Editing notes", err)
+		return nil
+	}
+	if err := cmd.Run(); err != nil {
+		ui.notifyError("Editor", err)
+	}
+	delete(ui.notes, issue.Identifier)
+	ui.detailsGeneration++
+	if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) == "" {
+		os.Remove(path)
+	}
+	return g.Resume()
+}
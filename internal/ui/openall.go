@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+
+	"lazylinear/internal/tui"
+)
+
+// openAllLimit caps how many browser tabs "open all" will launch at once
+const openAllLimit = 10
+
+// openAllFiltered asks for confirmation and then opens every issue in the
+// current filtered list in the browser
+func (ui *UI) openAllFiltered(g *tui.Gui, v *tui.View) error {
+	var urls []string
+	for _, issue := range ui.issues {
+		if issue.URL != "" {
+			urls = append(urls, issue.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	items := []pickerItem{
+		{label: "Cancel"},
+		{label: fmt.Sprintf("Open %d issues in the browser", len(urls)), value: "open"},
+	}
+	if len(urls) > openAllLimit {
+		items[1].disabled = true
+		items[1].reason = fmt.Sprintf("more than %d, narrow the filter first", openAllLimit)
+	}
+
+	ui.openPicker("Open all filtered issues?", items, func(item pickerItem) error {
+		if item.value != "open" {
+			return nil
+		}
+		for _, url := range urls {
+			cmd := browserCommand(url)
+			if cmd == nil {
+				ui.notifyError("Opening browser", fmt.Errorf("no browser opener found"))
+				return nil
+			}
+			if err := cmd.Start(); err != nil {
+				ui.notifyError("Opening browser", err)
+				return nil
+			}
+			go cmd.Wait()
+		}
+		ui.notify("Opened %d issues", len(urls))
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"context"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// mutateOptimistically shows edit applied to issue at once and runs mutate
+// in the background. On success the issue is reconciled with the server's
+// copy and done runs; on failure the issue is restored and the error shown.
+func (ui *UI) mutateOptimistically(issue api.Issue, action string, edit func(*api.Issue), mutate func(ctx context.Context) error, done func()) {
+	optimistic := issue
+	edit(&optimistic)
+	ui.store.UpsertIssue(optimistic)
+
+	go func() {
+		ctx := context.Background()
+		err := mutate(ctx)
+		var fresh *api.Issue
+		if err == nil {
+			// A failed reconcile keeps the optimistic copy until the next refresh
+			fresh, _ = ui.client.GetIssue(ctx, issue.ID)
+		}
+		ui.gui.Update(func(g *tui.Gui) error {
+			// The team may have been switched in the meantime
+			loaded := containsIssue(ui.store.All(), issue.ID)
+			if err != nil {
+				if loaded {
+					ui.store.UpsertIssue(issue)
+				}
+				ui.notifyError(action, err)
+				return nil
+			}
+			if fresh != nil && loaded {
+				ui.store.UpsertIssue(*fresh)
+			}
+			done()
+			return nil
+		})
+	}()
+}
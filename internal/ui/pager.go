@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"lazylinear/internal/tui"
+)
+
+// defaultPager is used when $PAGER is not set
+const defaultPager = "less"
+
+// openPager suspends the UI and pipes the selected issue, description and
+// comments included, into $PAGER for searching and scrolling there
+func (ui *UI) openPager(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	var content strings.Builder
+	width, _ := g.Size()
+	ui.renderIssue(&content, ui.issues[ui.selectedIssue], width)
+
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = defaultPager
+	}
+	cmd := exec.Command("sh", "-c", pager)
+	cmd.Stdin = strings.NewReader(content.String())
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		// Let less pass the rendered colors through
+		cmd.Env = append(cmd.Env, "LESS=R")
+	}
+
+	if err := g.Suspend(); err != nil {
+		ui.notifyError("Opening pager", err)
+		return nil
+	}
+	if err := cmd.Run(); err != nil {
+		ui.notifyError("Pager", err)
+	}
+	return g.Resume()
+}
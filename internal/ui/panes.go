@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"lazylinear/internal/tui"
+)
+
+const (
+	defaultListWidth = 40
+	minListWidth     = 15
+	maxListWidth     = 85
+	listWidthStep    = 5
+)
+
+// listWidth returns the issue list width as a percentage of the screen
+func (ui *UI) listWidth() int {
+	if ui.config.ListWidth < minListWidth || ui.config.ListWidth > maxListWidth {
+		return defaultListWidth
+	}
+	return ui.config.ListWidth
+}
+
+// resizeList changes the list/details split by delta percent and persists it
+func (ui *UI) resizeList(delta int) error {
+	width := ui.listWidth() + delta
+	if width < minListWidth {
+		width = minListWidth
+	}
+	if width > maxListWidth {
+		width = maxListWidth
+	}
+	ui.config.ListWidth = width
+	if err := ui.config.Save(); err != nil {
+		ui.notifyError("Saving config", err)
+	}
+	return nil
+}
+
+func (ui *UI) shrinkList(g *tui.Gui, v *tui.View) error {
+	return ui.resizeList(-listWidthStep)
+}
+
+func (ui *UI) growList(g *tui.Gui, v *tui.View) error {
+	return ui.resizeList(listWidthStep)
+}
+
+// toggleZoom switches the details pane between split and full-screen
+func (ui *UI) toggleZoom(g *tui.Gui, v *tui.View) error {
+	ui.config.ZoomDetails = !ui.config.ZoomDetails
+	if err := ui.config.Save(); err != nil {
+		ui.notifyError("Saving config", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// canEditIssue reports whether the viewer may modify issues in the team,
+// returning the reason when they may not. When the viewer could not be
+// loaded the check is left to the server.
+func (ui *UI) canEditIssue(teamID string) (bool, string) {
+	if ui.viewer == nil {
+		return true, ""
+	}
+	if ui.viewer.Admin || ui.viewer.IsMember(teamID) {
+		return true, ""
+	}
+	return false, "you are not a member of this team"
+}
+
+// teamMembers returns the members of a team, fetching them once per session
+func (ui *UI) teamMembers(teamID string) ([]api.User, error) {
+	if members, ok := ui.members[teamID]; ok {
+		return members, nil
+	}
+	members, err := ui.client.GetTeamMembers(context.Background(), teamID)
+	if err != nil {
+		return nil, err
+	}
+	ui.members[teamID] = members
+	return members, nil
+}
+
+// assignIssue opens the assignee picker for the selected issue. Only members
+// of the issue's team are offered; choices that the API would reject are
+// greyed out up front.
+func (ui *UI) assignIssue(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil || ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+
+	members, err := ui.teamMembers(issue.Team.ID)
+	if err != nil {
+		ui.notifyError("Loading team members", err)
+		return nil
+	}
+
+	allowed, reason := ui.canEditIssue(issue.Team.ID)
+	items := []pickerItem{{label: "Unassigned", disabled: !allowed, reason: reason}}
+	for _, member := range members {
+		item := pickerItem{label: member.Name, value: member.ID, disabled: !allowed, reason: reason}
+		if allowed && !member.Active {
+			item.disabled = true
+			item.reason = "deactivated"
+		}
+		if member.ID == issue.Assignee.ID {
+			item.label += " (current)"
+		}
+		items = append(items, item)
+	}
+
+	ui.openPicker(fmt.Sprintf("Assign %s", issue.Identifier), items, func(item pickerItem) error {
+		name := ""
+		for _, member := range members {
+			if member.ID == item.value {
+				name = member.Name
+			}
+		}
+		assign := func(i *api.Issue) {
+			i.Assignee.ID = item.value
+			i.Assignee.Name = name
+		}
+		mutate := func(ctx context.Context) error {
+			return ui.client.AssignIssue(ctx, issue.ID, item.value)
+		}
+		ui.mutateOptimistically(issue, "Assigning "+issue.Identifier, assign, mutate, func() {
+			previous := issue.Assignee.ID
+			ui.pushUndo("assignment of "+issue.Identifier, func(ctx context.Context) error {
+				return ui.client.AssignIssue(ctx, issue.ID, previous)
+			})
+			if item.value == "" {
+				ui.notify("Unassigned %s", issue.Identifier)
+			} else if item.value == ui.viewerID {
+				ui.notify("Assigned %s to you", issue.Identifier)
+			} else {
+				ui.notify("Assigned %s to %s", issue.Identifier, name)
+			}
+		})
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"lazylinear/internal/tui"
+)
+
+// pickerItem is a selectable row in a picker overlay. Disabled items are
+// shown greyed out with the reason and cannot be chosen.
+type pickerItem struct {
+	label    string
+	value    string
+	disabled bool
+	reason   string
+}
+
+// picker is a modal list overlay used by actions that need a choice
+type picker struct {
+	title    string
+	items    []pickerItem
+	selected int
+	onSelect func(item pickerItem) error
+	// filterable pickers narrow their items with a fuzzy query typed by the user
+	filterable bool
+	query      string
+}
+
+// openPicker shows a picker overlay, placing the cursor on the first enabled item
+func (ui *UI) openPicker(title string, items []pickerItem, onSelect func(item pickerItem) error) {
+	p := &picker{title: title, items: items, onSelect: onSelect}
+	p.selectFirst()
+	ui.picker = p
+}
+
+// openFilterPicker shows a picker overlay whose items are filtered as the user types
+func (ui *UI) openFilterPicker(title string, items []pickerItem, onSelect func(item pickerItem) error) {
+	ui.openPicker(title, items, onSelect)
+	ui.picker.filterable = true
+}
+
+// shown returns the items matching the query, best matches first
+func (p *picker) shown() []pickerItem {
+	if p.query == "" {
+		return p.items
+	}
+	type match struct {
+		item  pickerItem
+		score int
+	}
+	var matches []match
+	for _, item := range p.items {
+		if score, ok := fuzzyScore(p.query, item.label); ok {
+			matches = append(matches, match{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	items := make([]pickerItem, len(matches))
+	for i, m := range matches {
+		items[i] = m.item
+	}
+	return items
+}
+
+// selectFirst moves the cursor to the first enabled shown item
+func (p *picker) selectFirst() {
+	p.selected = 0
+	for i, item := range p.shown() {
+		if !item.disabled {
+			p.selected = i
+			return
+		}
+	}
+}
+
+// fuzzyScore reports whether the runes of query appear in order in s, ignoring
+// case, and scores the match: a whole word equal to the query ranks highest,
+// then a prefix, then runs of consecutive matches
+func fuzzyScore(query, s string) (int, bool) {
+	query, lower := strings.ToLower(query), strings.ToLower(s)
+	score := 0
+	for _, word := range strings.Fields(lower) {
+		if word == query {
+			score += 100
+			break
+		}
+	}
+	if strings.HasPrefix(lower, query) {
+		score += 50
+	}
+
+	target := []rune(lower)
+	pos, run := 0, 0
+	for _, r := range query {
+		found := false
+		for pos < len(target) {
+			c := target[pos]
+			pos++
+			if c == r {
+				found = true
+				run++
+				score += run
+				break
+			}
+			run = 0
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+// layoutPicker draws the active picker overlay
+func (ui *UI) layoutPicker(g *tui.Gui, maxX, maxY int) error {
+	if ui.picker == nil {
+		g.DeleteView("picker")
+		return nil
+	}
+
+	width := 50
+	if width > maxX-2 {
+		width = maxX - 2
+	}
+	height := len(ui.picker.items) + 1
+	if height > maxY-4 {
+		height = maxY - 4
+	}
+	if height < 2 {
+		height = 2
+	}
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+	if ui.picker.filterable && y0 < 3 {
+		// Leave room above the list for the filter input
+		height -= 3 - y0
+		y0 = 3
+	}
+
+	v, err := g.SetView("picker", x0, y0, x0+width, y0+height)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Highlight = true
+	}
+	v.Title = ui.picker.title + " (Enter to choose, Esc to cancel)"
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
+
+	v.Clear()
+	items := ui.picker.shown()
+	for _, item := range items {
+		if item.disabled {
+			line := item.label
+			if item.reason != "" {
+				line += " — " + item.reason
+			}
+			fmt.Fprintln(v, colorize("\033[37m", line))
+		} else {
+			fmt.Fprintln(v, item.label)
+		}
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(v, colorize("\033[37m", "No matches"))
+	}
+
+	_, h := v.Size()
+	oy := 0
+	if ui.picker.selected >= h {
+		oy = ui.picker.selected - h + 1
+	}
+	v.SetOrigin(0, oy)
+	v.SetCursor(0, ui.picker.selected-oy)
+
+	if !ui.picker.filterable {
+		g.DeleteView("pickerfilter")
+		g.SetCurrentView("picker")
+		return nil
+	}
+	fv, err := g.SetView("pickerfilter", x0, y0-3, x0+width, y0-1)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		fv.Title = "Filter"
+		fv.Editable = true
+		fv.Editor = tui.DefaultEditor
+	}
+	if query := strings.TrimSpace(fv.Buffer()); query != ui.picker.query {
+		ui.picker.query = query
+		ui.picker.selectFirst()
+	}
+	g.SetCurrentView("pickerfilter")
+	return nil
+}
+
+func (ui *UI) setPickerKeybindings(g *tui.Gui) error {
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "navigate", ui.pickerDown},
+		{tui.KeyArrowDown, "navigate", ui.pickerDown},
+		{'k', "navigate", ui.pickerUp},
+		{tui.KeyArrowUp, "navigate", ui.pickerUp},
+		{tui.KeyEnter, "choose", ui.pickerChoose},
+		{tui.KeyEsc, "cancel", ui.closePicker},
+		{'q', "cancel", ui.closePicker},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "picker", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+
+	// The filter input of filterable pickers types letters, so only
+	// non-printable keys navigate
+	filterBindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{tui.KeyArrowDown, "navigate", ui.pickerDown},
+		{tui.KeyArrowUp, "navigate", ui.pickerUp},
+		{tui.KeyEnter, "choose", ui.pickerChoose},
+		{tui.KeyEsc, "cancel", ui.closePicker},
+	}
+	for _, b := range filterBindings {
+		if err := ui.bind(g, "pickerfilter", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ui *UI) pickerDown(g *tui.Gui, v *tui.View) error {
+	if ui.picker != nil && ui.picker.selected < len(ui.picker.shown())-1 {
+		ui.picker.selected++
+	}
+	return nil
+}
+
+func (ui *UI) pickerUp(g *tui.Gui, v *tui.View) error {
+	if ui.picker != nil && ui.picker.selected > 0 {
+		ui.picker.selected--
+	}
+	return nil
+}
+
+func (ui *UI) pickerChoose(g *tui.Gui, v *tui.View) error {
+	p := ui.picker
+	if p == nil {
+		return nil
+	}
+	items := p.shown()
+	if p.selected < 0 || p.selected >= len(items) {
+		return nil
+	}
+	item := items[p.selected]
+	if item.disabled {
+		return nil
+	}
+	if err := ui.closePicker(g, v); err != nil {
+		return err
+	}
+	return p.onSelect(item)
+}
+
+func (ui *UI) closePicker(g *tui.Gui, v *tui.View) error {
+	ui.picker = nil
+	g.DeleteView("picker")
+	g.DeleteView("pickerfilter")
+	_, err := g.SetCurrentView("issues")
+	return err
+}
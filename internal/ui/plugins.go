@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lazylinear/internal/tui"
+)
+
+// pluginTimeout bounds how long a plugin may take to run an action
+const pluginTimeout = time.Minute
+
+// plugin is an executable in ~/.lazylinear/plugins bound to a key on the
+// issue list.
+//
+// At startup each executable is run as `plugin describe` and prints a JSON
+// manifest such as {"key": "Z", "description": "Open in our CRM"}. Pressing
+// the key runs it as `plugin run` with the selected issue as JSON on stdin and
+// the LAZYLINEAR_* variables of hooks in its environment; whatever it prints
+// is shown in a popup.
+type plugin struct {
+	path        string
+	key         rune
+	description string
+}
+
+// pluginManifest is what a plugin prints when described
+type pluginManifest struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+// pluginsDir is where plugin executables are installed
+func pluginsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lazylinear", "plugins"), nil
+}
+
+// loadPlugins describes every executable in the plugins directory and binds
+// each to its key. Plugins that fail to describe themselves, or that ask for
+// a key already in use, are skipped with a warning.
+func (ui *UI) loadPlugins(g *tui.Gui) error {
+	dir, err := pluginsDir()
+	if err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var skipped []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+		p, err := describePlugin(filepath.Join(dir, entry.Name()))
+		if err == nil && g.HasKeybinding("issues", p.key) {
+			err = fmt.Errorf("key %q is already in use", p.key)
+		}
+		if err != nil {
+			ui.log.Warn("skipping plugin", "path", filepath.Join(dir, entry.Name()), "err", err)
+			skipped = append(skipped, entry.Name())
+			continue
+		}
+		if err := ui.bind(g, "issues", p.key, "", ui.runPlugin(p)); err != nil {
+			return err
+		}
+		ui.plugins = append(ui.plugins, p)
+	}
+	sort.Slice(ui.plugins, func(i, j int) bool { return ui.plugins[i].key < ui.plugins[j].key })
+	if len(skipped) > 0 {
+		ui.notify("Skipped %s: %s (see the debug log)", plural(len(skipped), "plugin"), strings.Join(skipped, ", "))
+	}
+	return nil
+}
+
+// describePlugin runs an executable for its manifest
+func describePlugin(path string) (plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "describe").Output()
+	if err != nil {
+		return plugin{}, err
+	}
+	var manifest pluginManifest
+	if err := json.Unmarshal(out, &manifest); err != nil {
+		return plugin{}, fmt.Errorf("reading manifest: %w", err)
+	}
+	key, size := utf8.DecodeRuneInString(manifest.Key)
+	if size == 0 || size != len(manifest.Key) || key == ' ' {
+		return plugin{}, fmt.Errorf("key %q is not a single character", manifest.Key)
+	}
+	description := manifest.Description
+	if description == "" {
+		description = filepath.Base(path)
+	}
+	return plugin{path: path, key: key, description: description}, nil
+}
+
+// runPlugin returns the handler running a plugin on the selected issue in
+// the background
+func (ui *UI) runPlugin(p plugin) func(*tui.Gui, *tui.View) error {
+	return func(g *tui.Gui, v *tui.View) error {
+		if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+			return nil
+		}
+		issue := ui.issues[ui.selectedIssue]
+		data, err := json.Marshal(issue)
+		if err != nil {
+			return nil
+		}
+		ui.notify("Running %s on %s…", p.description, issue.Identifier)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+			defer cancel()
+			cmd := exec.CommandContext(ctx, p.path, "run")
+			cmd.Env = append(os.Environ(), issueEnv(issue)...)
+			cmd.Stdin = bytes.NewReader(data)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			err := cmd.Run()
+			ui.log.Debug("plugin", "path", p.path, "issue", issue.Identifier, "err", err)
+			ui.gui.Update(func(g *tui.Gui) error {
+				if err != nil {
+					if msg := strings.TrimSpace(stderr.String()); msg != "" {
+						err = fmt.Errorf("%w: %s", err, msg)
+					}
+					ui.notifyError(p.description, err)
+					return nil
+				}
+				if strings.TrimSpace(stdout.String()) == "" {
+					ui.notify("%s finished on %s", p.description, issue.Identifier)
+					return nil
+				}
+				ui.openPopup(p.description+": "+issue.Identifier, stdout.String())
+				return nil
+			})
+		}()
+		return nil
+	}
+}
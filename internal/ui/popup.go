@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"lazylinear/internal/tui"
+)
+
+// popup is a scrollable block of read-only text shown over the current screen
+type popup struct {
+	title string
+	text  string
+}
+
+// openPopup shows text in a popup until it is closed
+func (ui *UI) openPopup(title, text string) {
+	ui.popup = &popup{title: title, text: text}
+	ui.gui.DeleteView("popup")
+}
+
+// layoutPopup draws the open popup, if any
+func (ui *UI) layoutPopup(g *tui.Gui, maxX, maxY int) error {
+	if ui.popup == nil {
+		g.DeleteView("popup")
+		return nil
+	}
+	v, err := g.SetView("popup", 4, 2, maxX-5, maxY-3)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Wrap = true
+		fmt.Fprint(v, ui.text(strings.TrimRight(ui.popup.text, "\n")))
+	}
+	v.Title = ui.popup.title + " (j/k to scroll, Esc to close)"
+	g.SetCurrentView("popup")
+	return nil
+}
+
+func (ui *UI) setPopupKeybindings(g *tui.Gui) error {
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "scroll", ui.scrollPopup(1)},
+		{'k', "scroll", ui.scrollPopup(-1)},
+		{tui.KeyArrowDown, "scroll", ui.scrollPopup(1)},
+		{tui.KeyArrowUp, "scroll", ui.scrollPopup(-1)},
+		{tui.KeyEsc, "close", ui.closePopup},
+		{'q', "close", ui.closePopup},
+		{tui.KeyEnter, "close", ui.closePopup},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "popup", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrollPopup returns a handler moving the popup text by delta lines
+func (ui *UI) scrollPopup(delta int) func(*tui.Gui, *tui.View) error {
+	return func(g *tui.Gui, v *tui.View) error {
+		if v == nil {
+			return nil
+		}
+		_, oy := v.Origin()
+		_, height := v.Size()
+		last := max(v.Rows()-height, 0)
+		return v.SetOrigin(0, min(max(oy+delta, 0), last))
+	}
+}
+
+func (ui *UI) closePopup(g *tui.Gui, v *tui.View) error {
+	ui.popup = nil
+	g.DeleteView("popup")
+	_, err := g.SetCurrentView("issues")
+	return err
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+const (
+	// prefetchIdle is how long foreground requests must be quiet before prefetching
+	prefetchIdle = 5 * time.Second
+	// prefetchSpacing is the minimum delay between background requests
+	prefetchSpacing = 3 * time.Second
+	// prefetchBackoff is the delay after a failed background request
+	prefetchBackoff = time.Minute
+	// teamCacheTTL is how long a cached team issue list is used without refetching
+	teamCacheTTL = 5 * time.Minute
+)
+
+// teamCacheEntry holds a fetched issue list for one team
+type teamCacheEntry struct {
+	issues    []api.Issue
+	fetchedAt time.Time
+}
+
+// teamCache holds issue lists per team ID, shared with the prefetch worker
+type teamCache struct {
+	mu             sync.Mutex
+	entries        map[string]teamCacheEntry
+	lastForeground time.Time
+}
+
+func newTeamCache() *teamCache {
+	return &teamCache{entries: make(map[string]teamCacheEntry)}
+}
+
+// clear drops every cached issue list, such as after switching accounts
+func (c *teamCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]teamCacheEntry)
+}
+
+// store records a freshly fetched issue list; foreground fetches also
+// postpone background prefetching
+func (c *teamCache) store(teamID string, issues []api.Issue, foreground bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[teamID] = teamCacheEntry{issues: issues, fetchedAt: time.Now()}
+	if foreground {
+		c.lastForeground = time.Now()
+	}
+}
+
+// get returns the cached issues for a team and when they were fetched,
+// if they are still fresh
+func (c *teamCache) get(teamID string) ([]api.Issue, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[teamID]
+	if !ok || time.Since(entry.fetchedAt) > teamCacheTTL {
+		return nil, time.Time{}, false
+	}
+	return entry.issues, entry.fetchedAt, true
+}
+
+// nextStale returns a team whose cache entry is missing or expired, provided
+// no foreground request happened recently
+func (c *teamCache) nextStale(teams []api.Team) (api.Team, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.lastForeground) < prefetchIdle {
+		return api.Team{}, false
+	}
+	for _, team := range teams {
+		entry, ok := c.entries[team.ID]
+		if !ok || time.Since(entry.fetchedAt) > teamCacheTTL {
+			return team, true
+		}
+	}
+	return api.Team{}, false
+}
+
+// prefetchTeams fetches the issue lists of other teams into cache in the
+// background while the UI is idle, one request at a time, so team switching
+// is instant. It stops when ctx is cancelled.
+func (ui *UI) prefetchTeams(ctx context.Context, cache *teamCache, teams []api.Team) {
+	delay := prefetchSpacing
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = prefetchSpacing
+		team, ok := cache.nextStale(teams)
+		if !ok {
+			continue
+		}
+		issues, err := ui.client.GetIssues(ctx, team.ID)
+		if err != nil {
+			delay = prefetchBackoff
+			continue
+		}
+		cache.store(team.ID, issues, false)
+	}
+}
+
+// loadTeam shows the current team's issues, using the prefetched list when
+// it is fresh and fetching otherwise
+func (ui *UI) loadTeam(g *tui.Gui, v *tui.View) error {
+	if ui.allTeams() && ui.client != nil {
+		issues, err := ui.fetchAllTeams(false)
+		if err != nil {
+			ui.loadFailed(err)
+			return nil
+		}
+		ui.loadErr = nil
+		ui.store.SetIssues(issues)
+		ui.listedTeam = ui.store.TeamIndex()
+		ui.lastRefresh = time.Now()
+		return nil
+	}
+	if team, ok := ui.store.CurrentTeam(); ok {
+		if issues, fetchedAt, ok := ui.teamCache.get(team.ID); ok {
+			ui.store.SetIssues(issues)
+			ui.listedTeam = ui.store.TeamIndex()
+			ui.lastRefresh = fetchedAt
+			return nil
+		}
+	}
+	return ui.refreshIssues(g, v)
+}
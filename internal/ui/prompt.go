@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"lazylinear/internal/tui"
+)
+
+// prompt is a single-line text input shown over the current screen
+type prompt struct {
+	title    string
+	initial  string
+	onSubmit func(text string) error
+}
+
+// openPrompt asks for a line of text; onSubmit runs with the trimmed text
+// on Enter unless it is empty
+func (ui *UI) openPrompt(title, initial string, onSubmit func(text string) error) {
+	ui.prompt = &prompt{title: title, initial: initial, onSubmit: onSubmit}
+	ui.gui.DeleteView("prompt")
+}
+
+// layoutPrompt draws the active prompt above every other overlay
+func (ui *UI) layoutPrompt(g *tui.Gui, maxX, maxY int) error {
+	if ui.prompt == nil {
+		g.DeleteView("prompt")
+		return nil
+	}
+
+	width := 60
+	if width > maxX-2 {
+		width = maxX - 2
+	}
+	x0 := (maxX - width) / 2
+	y0 := maxY/2 - 1
+	v, err := g.SetView("prompt", x0, y0, x0+width, y0+2)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Editable = true
+		fmt.Fprint(v, ui.prompt.initial)
+		v.SetCursor(len(ui.prompt.initial), 0)
+	}
+	v.Title = ui.prompt.title + " (Enter to confirm, Esc to cancel)"
+	g.SetCurrentView("prompt")
+	return nil
+}
+
+func (ui *UI) setPromptKeybindings(g *tui.Gui) error {
+	if err := ui.bind(g, "prompt", tui.KeyEnter, "confirm", ui.submitPrompt); err != nil {
+		return err
+	}
+	return ui.bind(g, "prompt", tui.KeyEsc, "cancel", ui.closePrompt)
+}
+
+func (ui *UI) closePrompt(g *tui.Gui, v *tui.View) error {
+	ui.prompt = nil
+	g.DeleteView("prompt")
+	return nil
+}
+
+func (ui *UI) submitPrompt(g *tui.Gui, v *tui.View) error {
+	p := ui.prompt
+	text := strings.TrimSpace(v.Buffer())
+	ui.closePrompt(g, v)
+	if p == nil || text == "" {
+		return nil
+	}
+	return p.onSubmit(text)
+}
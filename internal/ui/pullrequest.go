@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// prTitle is the pull request title for an issue, led by its identifier so
+// Linear links the PR back to the issue
+func prTitle(issue api.Issue) string {
+	return fmt.Sprintf("%s %s", issue.Identifier, issue.Title)
+}
+
+// prBody closes the issue with Linear's magic word when the PR merges
+func prBody(issue api.Issue) string {
+	body := "Closes " + issue.Identifier
+	if issue.URL != "" {
+		body += "\n\n" + issue.URL
+	}
+	return body
+}
+
+// createPullRequest suspends the UI and runs gh pr create prefilled from the
+// selected issue, so gh can prompt for anything else it needs on the terminal
+func (ui *UI) createPullRequest(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	if _, err := exec.LookPath("gh"); err != nil {
+		ui.notifyError("Creating pull request", fmt.Errorf("gh CLI not found in PATH"))
+		return nil
+	}
+
+	cmd := exec.Command("gh", "pr", "create", "--title", prTitle(issue), "--body", prBody(issue))
+	cmd.Dir = ui.repoDir(issue)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := g.Suspend(); err != nil {
+		ui.notifyError("Creating pull request", err)
+		return nil
+	}
+	fmt.Print("\033[H\033[2J")
+	err := cmd.Run()
+	fmt.Print("\n-- Press Enter to return --")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		ui.notifyError("gh pr create", err)
+	}
+	return g.Resume()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// loadRecurrence looks up in the background, once per issue, whether the
+// issue was created by a recurring schedule. Like customer requests, lookup
+// errors just leave the section out.
+func (ui *UI) loadRecurrence(issue api.Issue) {
+	if ui.client == nil || issue.ID == "" {
+		return
+	}
+	if _, requested := ui.recurrence[issue.ID]; requested {
+		return
+	}
+	ui.recurrence[issue.ID] = nil
+	go func() {
+		template, err := ui.client.GetRecurringTemplate(context.Background(), issue.ID)
+		if err != nil || template == nil {
+			return
+		}
+		ui.gui.Update(func(g *tui.Gui) error {
+			ui.recurrence[issue.ID] = template
+			ui.detailsGeneration++
+			return nil
+		})
+	}()
+}
+
+// renderRecurrence notes the recurring schedule an issue belongs to. Only
+// the template's name is fetched; showing the next occurrence and pausing or
+// resuming the schedule are left to Linear's web app until the API exposes
+// them, so the line points there.
+func (ui *UI) renderRecurrence(w io.Writer, issue api.Issue) {
+	if template := ui.recurrence[issue.ID]; template != nil {
+		fmt.Fprintf(w, "Recurring: ↻ %s (o opens Linear to see the next date or pause it)\n", template.Name)
+	}
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// maxRelationTrail caps how many followed relations Backspace can retrace
+const maxRelationTrail = 50
+
+// relationRow is a relation as seen from one issue: how the other issue
+// relates to it, and the other issue
+type relationRow struct {
+	label string
+	issue api.RelatedIssue
+}
+
+// outgoingRelationLabels and incomingRelationLabels name relation types from
+// the source and the target issue's side respectively
+var (
+	outgoingRelationLabels = map[string]string{"blocks": "Blocks", "duplicate": "Duplicate of", "related": "Related to", "similar": "Similar to"}
+	incomingRelationLabels = map[string]string{"blocks": "Blocked by", "duplicate": "Duplicated by", "related": "Related to", "similar": "Similar to"}
+)
+
+// relationRows lists the issue's relations, blockers first
+func relationRows(issue api.Issue) []relationRow {
+	var rows []relationRow
+	for _, relation := range issue.InverseRelations.Nodes {
+		if label, ok := incomingRelationLabels[relation.Type]; ok && relation.Type == "blocks" {
+			rows = append(rows, relationRow{label, relation.Issue})
+		}
+	}
+	for _, relation := range issue.Relations.Nodes {
+		if label, ok := outgoingRelationLabels[relation.Type]; ok {
+			rows = append(rows, relationRow{label, relation.RelatedIssue})
+		}
+	}
+	for _, relation := range issue.InverseRelations.Nodes {
+		if label, ok := incomingRelationLabels[relation.Type]; ok && relation.Type != "blocks" {
+			rows = append(rows, relationRow{label, relation.Issue})
+		}
+	}
+	return rows
+}
+
+// renderRelations lists the issue's relations in the details pane once its
+// details, which name the related issues, are loaded
+func (ui *UI) renderRelations(w io.Writer, issue api.Issue) {
+	if !issue.Detailed {
+		return
+	}
+	rows := relationRows(issue)
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nRelations (g to follow):")
+	for _, row := range rows {
+		fmt.Fprintf(w, "  %-13s %s %s (%s)\n", row.label, colorize(ui.theme().identifier, row.issue.Identifier), ui.text(row.issue.Title), row.issue.State.Name)
+	}
+}
+
+// followRelation picks one of the selected issue's relations and jumps to
+// the related issue, remembering where it came from for Backspace
+func (ui *UI) followRelation(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	from := ui.issues[ui.selectedIssue]
+	if !from.Detailed {
+		ui.notify("%s is still loading", from.Identifier)
+		return nil
+	}
+	rows := relationRows(from)
+	if len(rows) == 0 {
+		ui.notify("%s has no relations", from.Identifier)
+		return nil
+	}
+	items := make([]pickerItem, len(rows))
+	for i, row := range rows {
+		items[i] = pickerItem{label: fmt.Sprintf("%-13s %s %s", row.label, row.issue.Identifier, ui.text(row.issue.Title)), value: row.issue.Identifier}
+	}
+	ui.openPicker("Go to related issue", items, func(item pickerItem) error {
+		ui.pushTrail(from.Identifier)
+		return ui.jumpToIssue(g, item.value)
+	})
+	return nil
+}
+
+// pushTrail records the issue a jump starts from, for relationBack
+func (ui *UI) pushTrail(identifier string) {
+	ui.relationTrail = append(ui.relationTrail, identifier)
+	if len(ui.relationTrail) > maxRelationTrail {
+		ui.relationTrail = ui.relationTrail[1:]
+	}
+}
+
+// relationBack returns to the issue the last followed relation started from
+func (ui *UI) relationBack(g *tui.Gui, v *tui.View) error {
+	n := len(ui.relationTrail)
+	if n == 0 {
+		return nil
+	}
+	identifier := ui.relationTrail[n-1]
+	ui.relationTrail = ui.relationTrail[:n-1]
+	return ui.jumpToIssue(g, identifier)
+}
+
+// jumpToIssue selects a listed issue by identifier, falling back to
+// focusIssue, which loads it and clears the filters hiding it
+func (ui *UI) jumpToIssue(g *tui.Gui, identifier string) error {
+	for i, issue := range ui.issues {
+		if issue.Identifier == identifier {
+			ui.selectRow(i)
+			if lv, err := g.View("issues"); err == nil {
+				setListCursor(lv, i)
+			}
+			return nil
+		}
+	}
+	return ui.focusIssue(g, identifier)
+}
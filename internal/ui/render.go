@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"lazylinear/internal/tui"
+)
+
+// dirty reports whether a pane must be redrawn because key, a summary of
+// what the pane shows, differs from what it was last drawn from, and records
+// key as drawn
+func (ui *UI) dirty(pane, key string) bool {
+	if ui.rendered[pane] == key {
+		return false
+	}
+	ui.rendered[pane] = key
+	return true
+}
+
+// invalidate redraws every pane on the next layout, after changes such as
+// the theme that alter how all of them render
+func (ui *UI) invalidate() {
+	ui.rendered = make(map[string]string)
+}
+
+// drawList rewrites the issue list when the listed issues, the width, or the
+// minute shown by relative timestamps changed since it was last drawn
+func (ui *UI) drawList(v *tui.View) {
+	width, _ := v.Size()
+	key := fmt.Sprintf("%d/%d/%d/%d", ui.listGeneration, len(ui.issues), width, time.Now().Unix()/60)
+	if !ui.dirty("issues", key) {
+		return
+	}
+	v.Clear()
+	for _, row := range ui.listRows(ui.issues, width) {
+		fmt.Fprintln(v, row)
+	}
+}
+
+// drawDetails rewrites the details pane when the selected issue, the listed
+// issues, what is loaded for them, the width, the comment selection, or the
+// minute shown by relative timestamps changed since it was last drawn, so
+// toasts and other redraws leave it untouched
+func (ui *UI) drawDetails(v *tui.View, render func(w io.Writer, width int)) {
+	width, _ := v.Size()
+	selected := ""
+	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+		selected = ui.issues[ui.selectedIssue].ID
+	}
+	key := fmt.Sprintf("%s/%d/%d/%d/%t/%t/%t/%t/%d/%d", selected, ui.listGeneration, ui.detailsGeneration, width,
+		ui.showHelp, ui.unauthorized(), ui.focusComments, ui.showDescriptionDiff, ui.selectedComment, time.Now().Unix()/60)
+	if !ui.dirty("details", key) {
+		return
+	}
+	v.Clear()
+	render(v, width)
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/script"
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+// loadScripts runs the Starlark scripts in ~/.lazylinear/scripts and adds
+// the views they define as tabs after the state views. Their actions are
+// bound later by bindScriptActions.
+func (ui *UI) loadScripts() {
+	dir, err := script.Dir()
+	if err != nil {
+		return
+	}
+	scripts, err := script.Load(dir)
+	if err != nil {
+		ui.log.Warn("loading scripts", "err", err)
+		ui.notify("Some scripts failed to load (see the debug log): %s", firstLine(err.Error()))
+	}
+	ui.scripts = scripts
+
+	computed := make(map[string]store.Computed)
+	for _, view := range scripts.Views {
+		if containsFold(ui.views, view.Name) {
+			ui.log.Warn("skipping script view with a taken name", "view", view.Name)
+			continue
+		}
+		ui.views = append(ui.views, view.Name)
+		computed[view.Name] = ui.computeView(view)
+	}
+	ui.store.SetComputed(computed)
+}
+
+// computeView adapts a script view to the store. A failing view lists no
+// issues and reports why.
+func (ui *UI) computeView(view script.View) store.Computed {
+	return func(issues []api.Issue) []api.Issue {
+		kept, err := view.Apply(issues)
+		if err != nil {
+			ui.log.Warn("script view failed", "view", view.Name, "err", err)
+			ui.gui.Update(func(g *tui.Gui) error {
+				ui.notifyError("View "+view.Name, err)
+				return nil
+			})
+			return nil
+		}
+		return kept
+	}
+}
+
+// bindScriptActions binds the keys of the script actions, skipping actions
+// that ask for a key already in use
+func (ui *UI) bindScriptActions(g *tui.Gui) error {
+	if ui.scripts == nil {
+		return nil
+	}
+	var bound []script.Action
+	for _, action := range ui.scripts.Actions {
+		if g.HasKeybinding("issues", action.Key) {
+			ui.log.Warn("skipping script action with a taken key", "action", action.Description, "key", string(action.Key))
+			ui.notify("Script action %s skipped: key %c is already in use", action.Description, action.Key)
+			continue
+		}
+		if err := ui.bind(g, "issues", action.Key, "", ui.runScriptAction(action)); err != nil {
+			return err
+		}
+		bound = append(bound, action)
+	}
+	ui.scripts.Actions = bound
+	return nil
+}
+
+// runScriptAction returns the handler running a script action on the
+// selected issue in the background. What the action returns is shown in a
+// popup, and the issues are reloaded to pick up its changes.
+func (ui *UI) runScriptAction(action script.Action) func(*tui.Gui, *tui.View) error {
+	return func(g *tui.Gui, v *tui.View) error {
+		if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) || ui.client == nil {
+			return nil
+		}
+		issue := ui.issues[ui.selectedIssue]
+		ui.notify("Running %s on %s…", action.Description, issue.Identifier)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+			defer cancel()
+			out, err := action.Run(ctx, ui.client, issue)
+			ui.gui.Update(func(g *tui.Gui) error {
+				if err != nil {
+					ui.log.Warn("script action failed", "action", action.Description, "err", err)
+					ui.notifyError(action.Description, err)
+				} else if strings.TrimSpace(out) != "" {
+					ui.openPopup(action.Description+": "+issue.Identifier, out)
+				} else {
+					ui.notify("%s finished on %s", action.Description, issue.Identifier)
+				}
+				return ui.refreshIssues(g, nil)
+			})
+		}()
+		return nil
+	}
+}
+
+// containsFold reports whether names holds name, ignoring case
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine returns the first line of s
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
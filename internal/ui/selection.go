@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// rebuildList re-renders the issue list from the store's filtered issues while
+// keeping the selection and the cursor on the same issues by ID. It runs after
+// every store action. If the issue under the cursor is no longer
+// listed, the cursor stays on the nearest row instead.
+func (ui *UI) rebuildList() {
+	selectedID := ""
+	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+		selectedID = ui.issues[ui.selectedIssue].ID
+	}
+	lv, err := ui.gui.View("issues")
+	cursorID, cursorRow := "", 0
+	if err == nil {
+		cursorRow = cursorIndex(lv)
+		if cursorRow < len(ui.issues) {
+			cursorID = ui.issues[cursorRow].ID
+		}
+	}
+
+	ui.issues, ui.tree = buildTree(ui.store.Issues(), ui.collapsedTree)
+	ui.listGeneration++
+
+	ui.selectedIssue = indexOfIssue(ui.issues, selectedID)
+	ui.issueSelected()
+	if lv == nil {
+		return
+	}
+	row := indexOfIssue(ui.issues, cursorID)
+	if row < 0 {
+		row = cursorRow
+	}
+	if row >= len(ui.issues) {
+		row = len(ui.issues) - 1
+	}
+	if row < 0 {
+		row = 0
+	}
+	setListCursor(lv, row)
+}
+
+// selectRow selects the listed issue at row i
+func (ui *UI) selectRow(i int) {
+	ui.selectedIssue = i
+	ui.issueSelected()
+}
+
+// issueSelected loads what the details pane shows for the selected issue and
+// runs the selection hooks. It runs when the selection or the listed issues
+// change rather than on every redraw; loads skip what is already loaded or
+// requested.
+func (ui *UI) issueSelected() {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return
+	}
+	issue := ui.issues[ui.selectedIssue]
+	ui.loadDetails(issue)
+	ui.loadStateSince(issue)
+	ui.loadHistory(issue)
+	ui.loadCustomerNeeds(issue)
+	ui.loadRecurrence(issue)
+	ui.noteDescription(issue)
+	ui.hookSelection(issue)
+	ui.setTerminalTitle(issue)
+}
+
+// indexOfIssue returns the position of the issue with the given ID, or -1
+func indexOfIssue(issues []api.Issue, id string) int {
+	if id == "" {
+		return -1
+	}
+	for i, issue := range issues {
+		if issue.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// setListCursor moves the list cursor to row, scrolling it into view
+func setListCursor(v *tui.View, row int) {
+	_, h := v.Size()
+	oy := 0
+	if row >= h {
+		oy = row - h + 1
+	}
+	v.SetOrigin(0, oy)
+	v.SetCursor(0, row-oy)
+}
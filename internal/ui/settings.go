@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// refreshIntervals are the auto-refresh choices in minutes (0 disables)
+var refreshIntervals = []int{0, 1, 5, 15, 30, 60}
+
+// setting is a single editable row on the settings screen
+type setting struct {
+	label string
+	value func() string
+	cycle func()
+}
+
+// settings returns the rows shown on the settings screen
+func (ui *UI) settings() []setting {
+	return []setting{
+		{
+			label: "API key",
+			value: func() string { return ui.maskedAPIKey() },
+			cycle: func() { ui.openPrompt("Linear API key", "", ui.submitAPIKey) },
+		},
+		{
+			label: "Assigned to me by default",
+			value: func() string { return strconv.FormatBool(ui.config.AssignedToMe) },
+			cycle: func() { ui.config.AssignedToMe = !ui.config.AssignedToMe },
+		},
+		{
+			label: "Preview on cursor",
+			value: func() string { return strconv.FormatBool(ui.config.PreviewOnCursor) },
+			cycle: func() { ui.config.PreviewOnCursor = !ui.config.PreviewOnCursor },
+		},
+		{
+			label: "Refresh interval",
+			value: func() string {
+				if ui.config.RefreshInterval == 0 {
+					return "off"
+				}
+				return fmt.Sprintf("%dm", ui.config.RefreshInterval)
+			},
+			cycle: func() {
+				ui.config.RefreshInterval = nextInt(refreshIntervals, ui.config.RefreshInterval)
+			},
+		},
+		{
+			label: "Theme",
+			value: func() string { return ui.themeName() },
+			cycle: func() {
+				ui.config.Theme = nextString(themeNames, ui.themeName())
+				ui.applyTheme()
+			},
+		},
+		{
+			label: "Default team",
+			value: func() string {
+				if ui.config.DefaultTeam == "" {
+					return "(first)"
+				}
+				return ui.config.DefaultTeam
+			},
+			cycle: func() {
+				keys := []string{""}
+				for _, team := range ui.store.Teams() {
+					keys = append(keys, team.Key)
+				}
+				ui.config.DefaultTeam = nextString(keys, ui.config.DefaultTeam)
+			},
+		},
+		{
+			label: "Default view",
+			value: func() string {
+				if ui.config.DefaultView == "" {
+					return ui.views[0]
+				}
+				return ui.config.DefaultView
+			},
+			cycle: func() {
+				ui.config.DefaultView = nextString(ui.views, ui.config.DefaultView)
+			},
+		},
+		{
+			label: "Alert on background changes",
+			value: func() string { return ui.alertMode() },
+			cycle: func() { ui.config.Alert = nextString(alertModes, ui.alertMode()) },
+		},
+		{
+			label: "Start issue on branch checkout",
+			value: func() string { return strconv.FormatBool(ui.config.StartOnBranch) },
+			cycle: func() { ui.config.StartOnBranch = !ui.config.StartOnBranch },
+		},
+		{
+			label: "Plain emoji shortcodes",
+			value: func() string { return strconv.FormatBool(ui.config.PlainEmoji) },
+			cycle: func() { ui.config.PlainEmoji = !ui.config.PlainEmoji },
+		},
+	}
+}
+
+// nextString returns the element following current in values, wrapping around
+func nextString(values []string, current string) string {
+	for i, v := range values {
+		if v == current {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
+
+// nextInt returns the element following current in values, wrapping around
+func nextInt(values []int, current int) int {
+	for i, v := range values {
+		if v == current {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
+
+// themeName returns the name of the active theme
+func (ui *UI) themeName() string {
+	if _, ok := themes[ui.config.Theme]; ok {
+		return ui.config.Theme
+	}
+	return "default"
+}
+
+// layoutSettings draws the settings overlay
+func (ui *UI) layoutSettings(g *tui.Gui, maxX, maxY int) error {
+	if !ui.showSettings {
+		g.DeleteView("settings")
+		return nil
+	}
+
+	rows := ui.settings()
+	width := 60
+	if width > maxX-2 {
+		width = maxX - 2
+	}
+	height := len(rows) + 1
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+
+	v, err := g.SetView("settings", x0, y0, x0+width, y0+height)
+	if err != nil {
+		if err != tui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Settings (Enter to change, Esc to save and close)"
+		v.Highlight = true
+	}
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
+
+	v.Clear()
+	for _, row := range rows {
+		fmt.Fprintf(v, "%-30s %s\n", row.label, row.value())
+	}
+	v.SetCursor(0, ui.selectedSetting)
+	g.SetCurrentView("settings")
+	return nil
+}
+
+func (ui *UI) setSettingsKeybindings(g *tui.Gui) error {
+	if err := ui.bind(g, "issues", 'S', "settings", ui.openSettings); err != nil {
+		return err
+	}
+	bindings := []struct {
+		key     interface{}
+		desc    string
+		handler func(*tui.Gui, *tui.View) error
+	}{
+		{'j', "navigate", ui.nextSetting},
+		{tui.KeyArrowDown, "navigate", ui.nextSetting},
+		{'k', "navigate", ui.prevSetting},
+		{tui.KeyArrowUp, "navigate", ui.prevSetting},
+		{tui.KeyEnter, "change", ui.cycleSetting},
+		{tui.KeySpace, "change", ui.cycleSetting},
+		{tui.KeyEsc, "close", ui.closeSettings},
+		{'q', "close", ui.closeSettings},
+	}
+	for _, b := range bindings {
+		if err := ui.bind(g, "settings", b.key, b.desc, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ui *UI) openSettings(g *tui.Gui, v *tui.View) error {
+	ui.showSettings = true
+	ui.selectedSetting = 0
+	return nil
+}
+
+func (ui *UI) nextSetting(g *tui.Gui, v *tui.View) error {
+	if ui.selectedSetting < len(ui.settings())-1 {
+		ui.selectedSetting++
+	}
+	return nil
+}
+
+func (ui *UI) prevSetting(g *tui.Gui, v *tui.View) error {
+	if ui.selectedSetting > 0 {
+		ui.selectedSetting--
+	}
+	return nil
+}
+
+func (ui *UI) cycleSetting(g *tui.Gui, v *tui.View) error {
+	rows := ui.settings()
+	if ui.selectedSetting >= 0 && ui.selectedSetting < len(rows) {
+		rows[ui.selectedSetting].cycle()
+		ui.invalidate()
+	}
+	return nil
+}
+
+// closeSettings persists the configuration and returns to the issue list
+func (ui *UI) closeSettings(g *tui.Gui, v *tui.View) error {
+	ui.showSettings = false
+	g.DeleteView("settings")
+	if _, err := g.SetCurrentView("issues"); err != nil {
+		return err
+	}
+	if err := ui.config.Save(); err != nil {
+		ui.notifyError("Saving settings", err)
+	}
+	return nil
+}
+
+// autoRefresh refreshes issues on the configured interval until the UI
+// exits. Issues and notifications are fetched on this goroutine and applied
+// in gui.Update, so a slow network never freezes the UI.
+func (ui *UI) autoRefresh() {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		// The UI goroutine decides whether a refresh is due, sending the
+		// alert mode if so
+		due := make(chan string, 1)
+		ui.gui.Update(func(g *tui.Gui) error {
+			interval := time.Duration(ui.config.RefreshInterval) * time.Minute
+			if ui.client == nil || interval == 0 || time.Since(ui.lastRefresh) < interval {
+				close(due)
+				return nil
+			}
+			due <- ui.alertMode()
+			return nil
+		})
+		mode, ok := <-due
+		if !ok {
+			continue
+		}
+
+		team := ui.store.TeamIndex()
+		issues, err := ui.fetchIssues()
+		checked := time.Now()
+		var notifications []api.Notification
+		if mode != "off" {
+			notifications, _ = ui.client.GetNotifications(context.Background())
+		}
+		ui.gui.Update(func(g *tui.Gui) error {
+			if ui.store.TeamIndex() != team {
+				// The team switch loaded its own issues meanwhile
+				return nil
+			}
+			before := ui.store.All()
+			ui.applyIssues(issues, err)
+			if err == nil {
+				ui.checkAlerts(g, before, notifications, checked)
+			}
+			return nil
+		})
+	}
+}
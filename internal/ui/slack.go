@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/slack"
+	"lazylinear/internal/tui"
+)
+
+// slackTimeout bounds posting an issue to Slack
+const slackTimeout = 10 * time.Second
+
+// defaultSlackNote is offered as the message accompanying a shared issue
+const defaultSlackNote = "Can someone take a look at this?"
+
+// sendToSlack shares the selected issue in one of the configured Slack
+// channels, asking for the channel when there are several and for a note
+func (ui *UI) sendToSlack(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	channels := make([]string, 0, len(ui.config.SlackWebhooks))
+	for channel := range ui.config.SlackWebhooks {
+		channels = append(channels, channel)
+	}
+	switch len(channels) {
+	case 0:
+		ui.notify("Add slack_webhooks to ~/.lazylinear/config.json to send issues to Slack")
+	case 1:
+		ui.promptSlackNote(issue, channels[0])
+	default:
+		sort.Strings(channels)
+		items := make([]pickerItem, len(channels))
+		for i, channel := range channels {
+			items[i] = pickerItem{label: channel, value: channel}
+		}
+		ui.openFilterPicker(fmt.Sprintf("Send %s to", issue.Identifier), items, func(item pickerItem) error {
+			ui.promptSlackNote(issue, item.value)
+			return nil
+		})
+	}
+	return nil
+}
+
+// promptSlackNote asks for the message accompanying the issue, then posts it
+func (ui *UI) promptSlackNote(issue api.Issue, channel string) {
+	ui.openPrompt(fmt.Sprintf("Message for %s", channel), defaultSlackNote, func(note string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), slackTimeout)
+		defer cancel()
+		if err := slack.Post(ctx, ui.config.SlackWebhooks[channel], slack.IssueMessage(issue, note)); err != nil {
+			ui.notifyError("Sending to Slack", err)
+			return nil
+		}
+		ui.notify("Sent %s to %s", issue.Identifier, channel)
+		return nil
+	})
+}
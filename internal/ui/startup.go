@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/config"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// startupTimeout bounds the initial fetch of teams, issues, and the viewer
+const startupTimeout = 30 * time.Second
+
+// startupData is what the first screen shows
+type startupData struct {
+	teams       []api.Team
+	currentTeam int
+	viewer      *api.Viewer
+	issues      []api.Issue
+}
+
+// fetchStartup loads the first screen. When a starting team is named, the
+// viewer, the teams, and that team's issues come back in one batched request;
+// when the batch misses the team or fails for a reason other than the API
+// key, the viewer, the teams, and the named team's issues are fetched side by
+// side. Without a named team, the first team's issues wait on the teams.
+func fetchStartup(client *api.Client, cfg *config.Config, teamRef string) (startupData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	if teamRef != "" {
+		batch, err := client.GetStartup(ctx, teamRef)
+		if errors.Is(err, api.ErrUnauthorized) {
+			return startupData{}, err
+		}
+		if err == nil {
+			teams := filterTeams(batch.Teams, cfg)
+			current := findTeam(teams, teamRef)
+			if len(teams) > 0 && teamMatches(teams[current], teamRef) {
+				issues := teamIssues(batch.Issues, teams[current].ID)
+				return startupData{teams: teams, currentTeam: current, viewer: &batch.Viewer, issues: issues}, nil
+			}
+		}
+	}
+
+	var data startupData
+	var refIssues []api.Issue
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		if teams, err := client.GetTeams(groupCtx); err == nil {
+			data.teams = filterTeams(teams, cfg)
+		}
+		return nil
+	})
+	if teamRef != "" {
+		group.Go(func() error {
+			var err error
+			refIssues, err = client.GetTeamIssues(groupCtx, teamRef)
+			return err
+		})
+	}
+	group.Go(func() error {
+		if viewer, err := client.GetViewer(groupCtx); err == nil {
+			data.viewer = viewer
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return data, err
+	}
+
+	teamID := ""
+	if len(data.teams) > 0 {
+		data.currentTeam = findTeam(data.teams, teamRef)
+		teamID = data.teams[data.currentTeam].ID
+		if teamRef != "" && teamMatches(data.teams[data.currentTeam], teamRef) {
+			data.issues = teamIssues(refIssues, teamID)
+			return data, nil
+		}
+	}
+	var err error
+	data.issues, err = client.GetIssues(ctx, teamID)
+	return data, err
+}
+
+// teamIssues keeps the issues of one team, as a reference may match a
+// second team's name with its key
+func teamIssues(issues []api.Issue, teamID string) []api.Issue {
+	var kept []api.Issue
+	for _, issue := range issues {
+		if issue.Team.ID == teamID {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// teamMatches reports whether ref names team by key, name, or ID
+func teamMatches(team api.Team, ref string) bool {
+	return strings.EqualFold(team.Key, ref) || strings.EqualFold(team.Name, ref) || team.ID == ref
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// renderSubscribers writes who is subscribed to the issue
+func (ui *UI) renderSubscribers(w io.Writer, issue api.Issue) {
+	if len(issue.Subscribers.Nodes) == 0 {
+		return
+	}
+	names := make([]string, len(issue.Subscribers.Nodes))
+	for i, user := range issue.Subscribers.Nodes {
+		names[i] = user.Name
+	}
+	fmt.Fprintf(w, "Subscribers: %s\n", strings.Join(names, ", "))
+}
+
+// addSubscriber opens a picker of the issue team's members and subscribes
+// the chosen one to the selected issue
+func (ui *UI) addSubscriber(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil || ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+
+	members, err := ui.teamMembers(issue.Team.ID)
+	if err != nil {
+		ui.notifyError("Loading team members", err)
+		return nil
+	}
+	subscribed := make(map[string]bool)
+	for _, user := range issue.Subscribers.Nodes {
+		subscribed[user.ID] = true
+	}
+
+	var items []pickerItem
+	for _, member := range members {
+		item := pickerItem{label: member.Name, value: member.ID}
+		switch {
+		case subscribed[member.ID]:
+			item.disabled = true
+			item.reason = "already subscribed"
+		case !member.Active:
+			item.disabled = true
+			item.reason = "deactivated"
+		}
+		items = append(items, item)
+	}
+
+	ui.openFilterPicker(fmt.Sprintf("Subscribe to %s", issue.Identifier), items, func(item pickerItem) error {
+		if err := ui.client.SubscribeToIssue(context.Background(), issue.ID, item.value); err != nil {
+			ui.notifyError("Subscribing", err)
+			return nil
+		}
+		if _, err := ui.reloadSelected(); err != nil {
+			ui.notifyError("Refresh", err)
+		}
+		ui.notify("Subscribed %s to %s", item.label, issue.Identifier)
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/export"
+	"lazylinear/internal/llm"
+	"lazylinear/internal/markdown"
+	"lazylinear/internal/tui"
+)
+
+// summarizeTimeout bounds waiting for the model's summary
+const summarizeTimeout = 2 * time.Minute
+
+// summarizeInstruction tells the model what summary to write
+const summarizeInstruction = "Summarize this Linear issue and its comment thread in 3 to 6 short markdown bullet points: " +
+	"the problem, decisions made, open questions, and next steps. Reply with the bullet points only."
+
+// llmClient returns the language model configured under "llm"
+func (ui *UI) llmClient() llm.Client {
+	cfg := ui.config.LLM
+	apiKey := cfg.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+	return llm.Client{Command: cfg.Command, Endpoint: cfg.Endpoint, Model: cfg.Model, APIKey: apiKey}
+}
+
+// summarizeIssue asks the configured model to summarize the selected issue
+// and its comments; the summary shows in the details pane when it arrives
+func (ui *UI) summarizeIssue(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	model := ui.llmClient()
+	if !model.Configured() {
+		ui.notify("Set llm.command or llm.endpoint in ~/.lazylinear/config.json to summarize issues")
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	if ui.summarizing[issue.ID] {
+		return nil
+	}
+	if !issue.Detailed && ui.client != nil {
+		full, err := ui.client.GetIssue(context.Background(), issue.ID)
+		if err != nil {
+			ui.notifyError("Summarizing "+issue.Identifier, err)
+			return nil
+		}
+		issue = *full
+	}
+
+	var transcript strings.Builder
+	if err := export.Note(&transcript, issue); err != nil {
+		return nil
+	}
+	ui.summarizing[issue.ID] = true
+	ui.detailsGeneration++
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), summarizeTimeout)
+		defer cancel()
+		summary, err := model.Complete(ctx, summarizeInstruction, transcript.String())
+		ui.gui.Update(func(g *tui.Gui) error {
+			delete(ui.summarizing, issue.ID)
+			ui.detailsGeneration++
+			if err != nil {
+				ui.notifyError("Summarizing "+issue.Identifier, err)
+				return nil
+			}
+			ui.summaries[issue.ID] = summary
+			return nil
+		})
+	}()
+	return nil
+}
+
+// renderSummary writes the model's summary of the issue, once requested
+func (ui *UI) renderSummary(w io.Writer, issue api.Issue, width int) {
+	if ui.summarizing[issue.ID] {
+		fmt.Fprintln(w, "\nSummary: summarizing…")
+		return
+	}
+	summary, ok := ui.summaries[issue.ID]
+	if !ok {
+		return
+	}
+	fmt.Fprintf(w, "\nSummary (T to redo):\n%s\n", markdown.Render(ui.text(summary), width))
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"lazylinear/internal/api"
+)
+
+// listSummary totals the listed issues for the list footer, e.g.
+// "23 issues · 58 points · 6 unassigned"
+func listSummary(issues []api.Issue) string {
+	var points float64
+	unassigned := 0
+	for _, issue := range issues {
+		if issue.Estimate != nil {
+			points += *issue.Estimate
+		}
+		if issue.Assignee.ID == "" {
+			unassigned++
+		}
+	}
+	return fmt.Sprintf(" %s · %s · %d unassigned ", plural(len(issues), "issue"), pluralFloat(points, "point"), unassigned)
+}
+
+// plural formats a count with its noun, e.g. "1 issue" or "3 issues"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// pluralFloat is plural for fractional totals such as estimates
+func pluralFloat(n float64, noun string) string {
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	if n == 1 {
+		return s + " " + noun
+	}
+	return s + " " + noun + "s"
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
+)
+
+// switchTeam opens a fuzzy team picker; typing a team's key ranks it first,
+// so "ENG" then Enter jumps straight to that team
+func (ui *UI) switchTeam(g *tui.Gui, v *tui.View) error {
+	teams := ui.store.Teams()
+	if len(teams) == 0 {
+		return nil
+	}
+	current := ui.store.TeamIndex()
+	items := []pickerItem{{label: fmt.Sprintf("%-6s %s", "*", "All teams"), value: strconv.Itoa(store.AllTeams)}}
+	for i, team := range teams {
+		items = append(items, pickerItem{label: fmt.Sprintf("%-6s %s", team.Key, team.Name), value: strconv.Itoa(i)})
+	}
+	for i := range items {
+		if items[i].value == strconv.Itoa(current) {
+			items[i].label += " (current)"
+		}
+	}
+	ui.openFilterPicker("Team", items, func(item pickerItem) error {
+		i, err := strconv.Atoi(item.value)
+		if err != nil || i == current || !ui.store.SelectTeam(i) {
+			return nil
+		}
+		return ui.loadTeam(g, v)
+	})
+	return nil
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+
+	"lazylinear/internal/api"
+)
+
+// terminalTitle renders the configured terminal_title template for an issue
+func (ui *UI) terminalTitle(issue api.Issue) string {
+	title := strings.NewReplacer(
+		"{identifier}", issue.Identifier,
+		"{title}", issue.Title,
+		"{state}", issue.State.Name,
+		"{team}", issue.Team.Key,
+	).Replace(ui.config.TerminalTitle)
+	// Control characters would end the escape sequence early
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, title)
+}
+
+// setTerminalTitle titles the terminal window, and the tmux window when
+// running inside tmux, after the selected issue. The terminal's own title
+// is saved first so restoreTerminalTitle can put it back.
+func (ui *UI) setTerminalTitle(issue api.Issue) {
+	if ui.config.TerminalTitle == "" {
+		return
+	}
+	title := ui.terminalTitle(issue)
+	if title == ui.shownTitle {
+		return
+	}
+	if ui.shownTitle == "" {
+		// Push the current title onto the xterm title stack
+		fmt.Fprint(os.Stdout, "\033[22;0t")
+	}
+	ui.shownTitle = title
+	fmt.Fprintf(os.Stdout, "\033]2;%s\007", title)
+	if os.Getenv("TMUX") != "" {
+		fmt.Fprintf(os.Stdout, "\033k%s\033\\", title)
+	}
+}
+
+// restoreTerminalTitle undoes setTerminalTitle on exit
+func (ui *UI) restoreTerminalTitle() {
+	if ui.shownTitle == "" {
+		return
+	}
+	fmt.Fprint(os.Stdout, "\033[23;0t")
+	if os.Getenv("TMUX") != "" {
+		// Naming the window turned tmux's automatic renaming off
+		exec.Command("tmux", "set-window-option", "-q", "automatic-rename", "on").Run()
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// theme holds the colors used when rendering panes. String fields are ANSI
+// escape prefixes; an empty string renders uncolored text.
+type theme struct {
+	identifier string
+	assignee   string
+	activeTeam string
+	toast      string
+	toastError string
+	// states colors the state glyph of list rows by canonical state name
+	states map[string]string
+	// urgent colors the marker shown on urgent issues
+	urgent string
+	// dueSoon and overdue color due date and SLA indicators as they approach
+	dueSoon string
+	overdue string
+	// aging colors the badge of issues without recent updates
+	aging string
+	// added and removed color the lines of description diffs
+	added   string
+	removed string
+	selBg   tui.Attribute
+	selFg   tui.Attribute
+	border  tui.Attribute
+}
+
+// themeNames lists the built-in themes in the order the settings screen cycles them
+var themeNames = []string{"default", "light", "mono"}
+
+var themes = map[string]theme{
+	"default": {
+		identifier: "\033[32m",
+		assignee:   "\033[33m",
+		activeTeam: "\033[32m",
+		toast:      "\033[32m",
+		toastError: "\033[31m",
+		states: map[string]string{
+			"In Review":   "\033[35m",
+			"In Progress": "\033[34m",
+			"Blocked":     "\033[31m",
+			"Todo":        "\033[37m",
+			"Backlog":     "\033[90m",
+		},
+		urgent:  "\033[31m",
+		dueSoon: "\033[33m",
+		overdue: "\033[31m",
+		aging:   "\033[90m",
+		added:   "\033[32m",
+		removed: "\033[31m",
+		selBg:   tui.ColorGreen,
+		selFg:   tui.ColorBlack,
+		border:  tui.ColorGreen,
+	},
+	"light": {
+		identifier: "\033[34m",
+		assignee:   "\033[35m",
+		activeTeam: "\033[34m",
+		toast:      "\033[34m",
+		toastError: "\033[31m",
+		states: map[string]string{
+			"In Review":   "\033[35m",
+			"In Progress": "\033[34m",
+			"Blocked":     "\033[31m",
+			"Backlog":     "\033[90m",
+		},
+		urgent:  "\033[31m",
+		dueSoon: "\033[33m",
+		overdue: "\033[31m",
+		aging:   "\033[90m",
+		added:   "\033[32m",
+		removed: "\033[31m",
+		selBg:   tui.ColorBlue,
+		selFg:   tui.ColorWhite,
+		border:  tui.ColorBlue,
+	},
+	"mono": {
+		toastError: "\033[1m",
+		urgent:     "\033[1m",
+		overdue:    "\033[1m",
+		added:      "\033[1m",
+		selBg:      tui.ColorWhite,
+		selFg:      tui.ColorBlack,
+		border:     tui.ColorWhite,
+	},
+}
+
+// theme returns the configured theme, falling back to the default, with any
+// theme_colors overrides from the config applied
+func (ui *UI) theme() theme {
+	t, ok := themes[ui.config.Theme]
+	if !ok {
+		t = themes["default"]
+	}
+	if len(ui.config.ThemeColors) == 0 {
+		return t
+	}
+	states := make(map[string]string, len(t.states))
+	for name, color := range t.states {
+		states[name] = color
+	}
+	for name, value := range ui.config.ThemeColors {
+		color, ok := ansiColor(value)
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(name, "urgent") {
+			t.urgent = color
+		} else {
+			states[name] = color
+		}
+	}
+	t.states = states
+	return t
+}
+
+// ansiColors are the color names accepted in theme_colors
+var ansiColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// ansiColor converts a configured color into an ANSI escape prefix. It accepts
+// a basic color name, optionally prefixed "bright-", a 256-color index, or a
+// #rrggbb hex value; "none" disables coloring.
+func ansiColor(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "none" {
+		return "", true
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n < 256 {
+		return fmt.Sprintf("\033[38;5;%dm", n), true
+	}
+	if hex := strings.TrimPrefix(value, "#"); len(hex) == 6 && hex != value {
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return "", false
+		}
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", rgb>>16, rgb>>8&0xff, rgb&0xff), true
+	}
+	base := 30
+	if name, ok := strings.CutPrefix(value, "bright-"); ok {
+		base, value = 90, name
+	}
+	for i, name := range ansiColors {
+		if name == value {
+			return fmt.Sprintf("\033[%dm", base+i), true
+		}
+	}
+	return "", false
+}
+
+// stateGlyphs mark each canonical state with a distinct shape, so the list is
+// readable without color
+var stateGlyphs = map[string]string{
+	"In Review":   "◕",
+	"In Progress": "◐",
+	"Blocked":     "✖",
+	"Todo":        "○",
+	"Backlog":     "◌",
+}
+
+// statusMarker renders the colored state glyph and urgent marker that lead
+// each issue list row
+func (ui *UI) statusMarker(issue api.Issue) string {
+	t := ui.theme()
+	state := ui.stateName(issue)
+	glyph, ok := stateGlyphs[state]
+	if !ok {
+		glyph = "●"
+	}
+	marker := colorize(t.states[state], glyph)
+	if issue.Priority == 1 {
+		return marker + colorize(t.urgent, "!")
+	}
+	return marker + " "
+}
+
+// colorize wraps s in the given ANSI color prefix and a reset
+func colorize(color, s string) string {
+	if color == "" {
+		return s
+	}
+	return color + s + "\033[0m"
+}
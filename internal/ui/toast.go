@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"lazylinear/internal/tui"
+)
+
+// toastDuration is how long a status bar message stays visible
+const toastDuration = 4 * time.Second
+
+// toast is a transient status bar message reporting the result of an action
+type toast struct {
+	message string
+	failed  bool
+	expires time.Time
+}
+
+// notify shows a success message in the status bar
+func (ui *UI) notify(format string, args ...interface{}) {
+	ui.showToast(fmt.Sprintf(format, args...), false)
+}
+
+// notifyError shows a failure message in the status bar
+func (ui *UI) notifyError(action string, err error) {
+	ui.log.Error(action+" failed", "error", err)
+	ui.showToast(fmt.Sprintf("%s failed: %v", action, err), true)
+}
+
+// showToast replaces the current toast and schedules a redraw for when it expires
+func (ui *UI) showToast(message string, failed bool) {
+	ui.toast = &toast{message: message, failed: failed, expires: time.Now().Add(toastDuration)}
+	if ui.gui == nil {
+		return
+	}
+	time.AfterFunc(toastDuration, func() {
+		ui.gui.Update(func(g *tui.Gui) error { return nil })
+	})
+}
+
+// activeToast returns the current toast, clearing it once expired
+func (ui *UI) activeToast() *toast {
+	if ui.toast != nil && time.Now().After(ui.toast.expires) {
+		ui.toast = nil
+	}
+	return ui.toast
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"strings"
+
+	"lazylinear/internal/api"
+	"lazylinear/internal/tui"
+)
+
+// treeNode places a listed issue in the sub-issue tree
+type treeNode struct {
+	depth int
+	// children counts the issue's sub-issues that pass the filter
+	children int
+}
+
+// buildTree nests each sub-issue beneath its parent when the parent is
+// listed too, keeping the filter's order among siblings. Sub-issues of
+// collapsed parents are left out.
+func buildTree(issues []api.Issue, collapsed map[string]bool) ([]api.Issue, map[string]treeNode) {
+	listed := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		listed[issue.ID] = true
+	}
+	children := make(map[string][]api.Issue)
+	var roots []api.Issue
+	for _, issue := range issues {
+		if issue.Parent != nil && listed[issue.Parent.ID] {
+			children[issue.Parent.ID] = append(children[issue.Parent.ID], issue)
+			continue
+		}
+		roots = append(roots, issue)
+	}
+
+	ordered := make([]api.Issue, 0, len(issues))
+	nodes := make(map[string]treeNode, len(issues))
+	var walk func(issue api.Issue, depth int)
+	walk = func(issue api.Issue, depth int) {
+		if _, seen := nodes[issue.ID]; seen {
+			return
+		}
+		ordered = append(ordered, issue)
+		nodes[issue.ID] = treeNode{depth: depth, children: len(children[issue.ID])}
+		if collapsed[issue.ID] {
+			return
+		}
+		for _, child := range children[issue.ID] {
+			walk(child, depth+1)
+		}
+	}
+	for _, issue := range roots {
+		walk(issue, 0)
+	}
+	return ordered, nodes
+}
+
+// treeMarker indents a list row by its depth in the sub-issue tree and
+// marks parents as expanded (▾) or collapsed (▸)
+func (ui *UI) treeMarker(issue api.Issue) string {
+	node := ui.tree[issue.ID]
+	marker := strings.Repeat("  ", node.depth)
+	if node.children == 0 {
+		return marker
+	}
+	if ui.collapsedTree[issue.ID] {
+		return marker + "▸ "
+	}
+	return marker + "▾ "
+}
+
+// toggleTree collapses or expands the selected issue's sub-issues
+func (ui *UI) toggleTree(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	return ui.setCollapsed(issue.ID, !ui.collapsedTree[issue.ID])
+}
+
+// collapseTree hides the selected issue's sub-issues, or moves to the
+// parent of a sub-issue
+func (ui *UI) collapseTree(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	if ui.tree[issue.ID].children > 0 && !ui.collapsedTree[issue.ID] {
+		return ui.setCollapsed(issue.ID, true)
+	}
+	if issue.Parent == nil {
+		return nil
+	}
+	if i := indexOfIssue(ui.issues, issue.Parent.ID); i >= 0 {
+		ui.selectRow(i)
+		setListCursor(v, i)
+	}
+	return nil
+}
+
+// expandTree shows the selected issue's sub-issues
+func (ui *UI) expandTree(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	return ui.setCollapsed(ui.issues[ui.selectedIssue].ID, false)
+}
+
+// setCollapsed records whether a parent's sub-issues are hidden and
+// rebuilds the list
+func (ui *UI) setCollapsed(id string, collapsed bool) error {
+	if ui.tree[id].children == 0 || ui.collapsedTree[id] == collapsed {
+		return nil
+	}
+	if collapsed {
+		ui.collapsedTree[id] = true
+	} else {
+		delete(ui.collapsedTree, id)
+	}
+	ui.rebuildList()
+	return nil
+}
@@ -3,31 +3,142 @@ package ui
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"os/exec"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/jroimartin/gocui"
 	"lazylinear/internal/api"
+	"lazylinear/internal/clipboard"
+	"lazylinear/internal/config"
+	"lazylinear/internal/emoji"
+	"lazylinear/internal/markdown"
+	"lazylinear/internal/script"
+	"lazylinear/internal/store"
+	"lazylinear/internal/tui"
 )
 
 // UI manages the terminal user interface
 type UI struct {
-	gui            *gocui.Gui
+	gui            *tui.Gui
 	client         *api.Client
+	config         *config.Config
+	log            *slog.Logger
+	store          *store.Store
 	issues         []api.Issue
-	allIssues      []api.Issue
 	selectedIssue  int
 	showHelp       bool
 	showSearch     bool
-	searchString   string
-	assignedToMe   bool
 	viewerID       string
+	viewer         *api.Viewer
+	members        map[string][]api.User
+	picker         *picker
+	prompt         *prompt
+	popup          *popup
+	mention        *mention
 	currentView    int
 	views          []string
-	teams          []api.Team
-	currentTeam    int
+	hints          []keyHint
 	showComment    bool
 	commentContent string
+	// Comment selection in the details pane
+	focusComments    bool
+	selectedComment  int
+	editingCommentID string
+	collapsedThreads map[string]bool
+	// Root comment of the thread the composer replies in, if any
+	replyToID   string
+	replyToName string
+	// Settings screen
+	showSettings    bool
+	selectedSetting int
+	lastRefresh     time.Time
+	teamCache       *teamCache
+	// Stops the background prefetch worker, if one is running
+	stopPrefetch context.CancelFunc
+	// Issue creation
+	creatingIssue bool
+	lintWarnings  []string
+	// Whether the drafted issue is out with the model to be expanded
+	expandingDraft bool
+	// Executables from ~/.lazylinear/plugins, by key
+	plugins []plugin
+	// Views and actions defined by Starlark scripts
+	scripts *script.Scripts
+	// Notifications inbox
+	showInbox      bool
+	notifications  []api.Notification
+	selectedInbox  int
+	collapsedInbox map[int]bool
+	// Sub-issue nesting of the list, and the parents whose sub-issues are
+	// hidden, keyed by issue ID
+	tree          map[string]treeNode
+	collapsedTree map[string]bool
+	// Descriptions last viewed, keyed by issue ID and loaded on first use,
+	// and the earlier text of those that changed since
+	seenDescriptions    map[string]seenDescription
+	descriptionChanges  map[string]string
+	showDescriptionDiff bool
+	// Running focus timer, if any
+	focus *focusTimer
+	// Issue the external time tracker was started on, if any
+	tracking *api.Issue
+	// Configured shell hooks, run in order by one background worker
+	hookOnce  sync.Once
+	hookQueue chan func()
+	// What the event hooks last saw: the selected issue's ID, and the state
+	// of each loaded issue keyed by issue ID
+	hookedSelection string
+	hookedStates    map[string]string
+	// Terminal title set from terminal_title, empty until first set
+	shownTitle string
+	// Model summaries of issues, and the issues being summarized, keyed by
+	// issue ID
+	summaries   map[string]string
+	summarizing map[string]bool
+	// Local notes read from disk, keyed by issue identifier
+	notes map[string]string
+	// Issues to return to with Backspace after following relations
+	relationTrail []string
+	// Labels browser
+	showLabels    bool
+	labels        []api.Label
+	selectedLabel int
+	// Team members workload view
+	showMembers    bool
+	workloads      []workload
+	selectedMember int
+	// When each issue entered its current state, keyed by issue ID
+	stateSince map[string]time.Time
+	// Issues whose full details are being fetched, keyed by issue ID
+	detailsRequested map[string]bool
+	// Change history of each issue, keyed by issue ID
+	history map[string][]api.HistoryEntry
+	// Customer requests of each issue, keyed by issue ID
+	customerNeeds map[string][]api.CustomerNeed
+	// Recurring issue template of each issue, keyed by issue ID
+	recurrence map[string]*api.RecurringTemplate
+	// Inputs each pane was last drawn from, and counters bumped whenever
+	// the listed issues, or what is loaded for their details, change
+	rendered          map[string]string
+	listGeneration    int
+	detailsGeneration int
+	// Reversals of recent mutations, most recent last
+	undoStack []undoEntry
+	// Transient status bar message
+	toast *toast
+	// Last failure loading issues, shown in the error banner until retried or dismissed
+	loadErr error
+	// Team index whose issues are listed, which differs from the store's
+	// while a switch to another team has not loaded
+	listedTeam int
+	// Background change alerts
+	alertSince time.Time
+	flashUntil time.Time
 }
 
 // commentEditor is a custom editor that handles Esc key
@@ -35,183 +146,533 @@ type commentEditor struct {
 	ui *UI
 }
 
-func (e *commentEditor) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+func (e *commentEditor) Edit(v *tui.View, key tui.Key, ch rune, mod tui.Modifier) {
 	// Handle Esc key to cancel
-	if key == gocui.KeyEsc {
+	if key == tui.KeyEsc {
 		e.ui.cancelComment(e.ui.gui, v)
 		return
 	}
 	// Handle Ctrl+S to submit
-	if key == gocui.KeyCtrlS {
+	if key == tui.KeyCtrlS {
 		e.ui.submitComment(e.ui.gui, v)
 		return
 	}
+	if e.ui.editMention(v, key, ch) {
+		return
+	}
 	// Pass all other keys to default editor
-	gocui.DefaultEditor.Edit(v, key, ch, mod)
+	tui.DefaultEditor.Edit(v, key, ch, mod)
+}
+
+// searchEditor filters the list as the search text is typed
+type searchEditor struct {
+	ui *UI
+}
+
+func (e *searchEditor) Edit(v *tui.View, key tui.Key, ch rune, mod tui.Modifier) {
+	tui.DefaultEditor.Edit(v, key, ch, mod)
+	search := strings.TrimSpace(v.Buffer())
+	e.ui.store.UpdateFilter(func(f *store.Filter) { f.Search = search })
+}
+
+// Options pre-apply filters at startup without changing the saved config
+type Options struct {
+	// Team selects the initial team by key, name, or ID instead of default_team
+	Team string
+	// AssignedToMe starts filtered to the viewer's issues
+	AssignedToMe bool
+	// View selects the initial view tab instead of default_view
+	View string
+	// Logger receives UI events such as key presses and failures; nil
+	// discards them
+	Logger *slog.Logger
 }
 
 // NewUI creates a new UI instance
-func NewUI(client *api.Client) (*UI, error) {
-	g, err := gocui.NewGui(gocui.OutputNormal)
+func NewUI(client *api.Client, cfg *config.Config, opts Options) (*UI, error) {
+	g, err := tui.NewGui()
 	if err != nil {
 		return nil, err
 	}
 
-	// Enable InputEsc mode to handle Esc key properly
-	g.InputEsc = true
-
 	// Enable highlighting and set border colors like lazygit
 	g.Highlight = true
-	g.SelFgColor = gocui.ColorGreen  // Active pane border color
-	g.FgColor = gocui.ColorDefault   // Inactive pane border color
+	g.FgColor = tui.ColorDefault // Inactive pane border color
 
-	// Fetch teams and issues
+	// Fetch teams, issues, and the viewer
 	var issues []api.Issue
 	var teams []api.Team
 	var viewerID string
+	var currentViewer *api.Viewer
 	var apiErr error
-	var fetchedIssues []api.Issue
+	currentTeam := 0
 	if client != nil {
-		if fetchedTeams, err := client.GetTeams(context.Background()); err == nil {
-			teams = fetchedTeams
+		teamRef := cfg.DefaultTeam
+		if opts.Team != "" {
+			teamRef = opts.Team
 		}
-		teamID := ""
-		if len(teams) > 0 {
-			teamID = teams[0].ID
+		var data startupData
+		data, apiErr = fetchStartup(client, cfg, teamRef)
+		teams, currentTeam, currentViewer = data.teams, data.currentTeam, data.viewer
+		if currentViewer != nil {
+			viewerID = currentViewer.ID
 		}
-		fetchedIssues, apiErr = client.GetIssues(context.Background(), teamID)
-		if viewer, err := client.GetViewer(context.Background()); err == nil {
-			viewerID = viewer.ID
+		if apiErr == nil {
+			issues = data.issues
 		}
 	} else {
 		apiErr = fmt.Errorf("no client")
 	}
-	if apiErr == nil {
-		issues = fetchedIssues
-	} else {
-		issues = []api.Issue{{Title: fmt.Sprintf("Error loading issues: %v", apiErr)}}
+
+	canonical := func(name string) string { return name }
+	if client != nil {
+		canonical = client.CanonicalState
+	}
+	st := store.New(canonical)
+	st.SetTeams(teams, currentTeam)
+	st.SetViewer(viewerID)
+	st.SetIssues(issues)
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
 	}
 
 	ui := &UI{
-		gui:            g,
-		client:         client,
-		issues:         issues,
-		allIssues:      issues,
-		selectedIssue:  -1,
-		showHelp:       false,
-		showSearch:     false,
-		searchString:   "",
-		assignedToMe:   false,
-		viewerID:       viewerID,
-		currentView:    0,
-		views:          []string{"All", "In Review", "In Progress", "Blocked", "Todo", "Backlog"},
-		teams:          teams,
-		currentTeam:    0,
-		showComment:    false,
-		commentContent: "",
+		gui:                g,
+		client:             client,
+		config:             cfg,
+		log:                logger,
+		store:              st,
+		selectedIssue:      -1,
+		showHelp:           false,
+		showSearch:         false,
+		viewerID:           viewerID,
+		viewer:             currentViewer,
+		members:            make(map[string][]api.User),
+		currentView:        0,
+		views:              append([]string{"All"}, api.DefaultStates...),
+		showComment:        false,
+		commentContent:     "",
+		lastRefresh:        time.Now(),
+		teamCache:          newTeamCache(),
+		collapsedInbox:     make(map[int]bool),
+		collapsedTree:      make(map[string]bool),
+		descriptionChanges: make(map[string]string),
+		notes:              make(map[string]string),
+		summaries:          make(map[string]string),
+		summarizing:        make(map[string]bool),
+		hookedStates:       make(map[string]string),
+		collapsedThreads:   make(map[string]bool),
+		stateSince:         make(map[string]time.Time),
+		detailsRequested:   make(map[string]bool),
+		history:            make(map[string][]api.HistoryEntry),
+		customerNeeds:      make(map[string][]api.CustomerNeed),
+		recurrence:         make(map[string]*api.RecurringTemplate),
+		rendered:           make(map[string]string),
+		loadErr:            apiErr,
+		listedTeam:         currentTeam,
+		alertSince:         time.Now(),
+	}
+	if apiErr == nil && len(teams) > 0 {
+		ui.teamCache.store(teams[currentTeam].ID, issues, true)
+	}
+	ui.applyTheme()
+
+	ui.loadScripts()
+	startView := cfg.DefaultView
+	if opts.View != "" {
+		startView = opts.View
+	}
+	for i, view := range ui.views {
+		if strings.EqualFold(view, startView) {
+			ui.currentView = i
+			break
+		}
 	}
+	st.Subscribe(ui.rebuildList)
+	st.Subscribe(ui.hookStateChanges)
+	st.SetFilter(store.Filter{
+		View:         ui.views[ui.currentView],
+		AssignedToMe: cfg.AssignedToMe || opts.AssignedToMe,
+	})
 
 	g.SetManagerFunc(ui.layout)
 
 	// Set keybindings
-	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, ui.quit); err != nil {
+	if err := ui.bind(g, "", tui.KeyCtrlC, "quit", ui.quit); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", tui.KeyArrowDown, "navigate", ui.cursorDown); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", tui.KeyArrowUp, "navigate", ui.cursorUp); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'j', "navigate", ui.cursorDown); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'k', "navigate", ui.cursorUp); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'r', "refresh", ui.refreshIssues); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'h', "help", ui.toggleHelp); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'a', "my issues", ui.toggleAssigned); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'p', "", ui.togglePreview); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'w', "", ui.toggleStartable); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 's', "", ui.toggleStalest); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'd', "", ui.toggleDueThisWeek); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", gocui.KeyArrowDown, gocui.ModNone, ui.cursorDown); err != nil {
+	if err := ui.bind(g, "issues", 'D', "", ui.toggleOverdue); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", gocui.KeyArrowUp, gocui.ModNone, ui.cursorUp); err != nil {
+	if err := ui.bind(g, "issues", '/', "search", ui.toggleSearch); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", 'j', gocui.ModNone, ui.cursorDown); err != nil {
+	if err := ui.bind(g, "issues", tui.KeySpace, "collapse", ui.toggleTree); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", 'k', gocui.ModNone, ui.cursorUp); err != nil {
+	if err := ui.bind(g, "issues", 'g', "related", ui.followRelation); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", 'r', gocui.ModNone, ui.refreshIssues); err != nil {
+	if err := ui.bind(g, "issues", 'f', "", ui.openLink); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", 'h', gocui.ModNone, ui.toggleHelp); err != nil {
+	if err := ui.bind(g, "issues", 'x', "", ui.toggleDescriptionDiff); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", 'a', gocui.ModNone, ui.toggleAssigned); err != nil {
+	if err := ui.bind(g, "issues", 'N', "", ui.editNote); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", '/', gocui.ModNone, ui.toggleSearch); err != nil {
+	if err := ui.bind(g, "issues", 'F', "", ui.toggleFocus); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", '[', gocui.ModNone, ui.prevView); err != nil {
+	if err := ui.bind(g, "issues", 'K', "", ui.sendToSlack); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", ']', gocui.ModNone, ui.nextView); err != nil {
+	if err := ui.bind(g, "issues", 'V', "", ui.exportToVault); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", gocui.KeyEnter, gocui.ModNone, ui.selectIssue); err != nil {
+	if err := ui.bind(g, "issues", 'T', "", ui.summarizeIssue); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", ',', gocui.ModNone, ui.copyURL); err != nil {
+	if err := ui.bind(g, "issues", tui.KeyBackspace, "", ui.relationBack); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", '.', gocui.ModNone, ui.copyBranch); err != nil {
+	if err := ui.bind(g, "issues", tui.KeyBackspace2, "", ui.relationBack); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", '{', gocui.ModNone, ui.prevTeam); err != nil {
+	if err := ui.bind(g, "issues", tui.KeyArrowLeft, "", ui.collapseTree); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", '}', gocui.ModNone, ui.nextTeam); err != nil {
+	if err := ui.bind(g, "issues", tui.KeyArrowRight, "", ui.expandTree); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("issues", 'c', gocui.ModNone, ui.toggleComment); err != nil {
+	if err := ui.bind(g, "issues", '[', "switch view", ui.prevView); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("search", gocui.KeyEnter, gocui.ModNone, ui.closeSearch); err != nil {
+	if err := ui.bind(g, "issues", ']', "switch view", ui.nextView); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("search", gocui.KeyEsc, gocui.ModNone, ui.cancelSearch); err != nil {
+	for i := 0; i < 9; i++ {
+		if err := ui.bind(g, "issues", rune('1'+i), "", ui.jumpToView(i)); err != nil {
+			return nil, err
+		}
+	}
+	if err := ui.bind(g, "issues", tui.KeyEnter, "select", ui.selectIssue); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", ',', "", ui.copyURL); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'o', "", ui.openIssue); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'O', "", ui.openAllFiltered); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", '.', "", ui.copyBranch); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'b', "", ui.checkoutBranch); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'L', "", ui.openAttachment); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'G', "", ui.createPullRequest); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'C', "", ui.showCommitIssues); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'E', "", ui.exportView); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("comment", gocui.KeyCtrlS, gocui.ModNone, ui.submitComment); err != nil {
+	if err := ui.bind(g, "issues", 'W', "", ui.addSubscriber); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("comment", gocui.KeyCtrlQ, gocui.ModNone, ui.cancelComment); err != nil {
+	if err := ui.bind(g, "issues", 'm', "", ui.copyMarkdownLink); err != nil {
 		return nil, err
 	}
-	if err := g.SetKeybinding("comment", gocui.KeyEsc, gocui.ModNone, ui.cancelComment); err != nil {
+	if err := ui.bind(g, "issues", '#', "", ui.copyIdentifier); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", '{', "", ui.prevTeam); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", '}', "", ui.nextTeam); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 't', "team", ui.switchTeam); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'c', "comment", ui.toggleComment); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'n', "new", ui.newIssue); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", tui.KeyTab, "", ui.focusCommentList); err != nil {
+		return nil, err
+	}
+	if err := ui.setCommentKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setSettingsKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setPromptKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setPopupKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setInboxKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setLabelsKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setMembersKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.setPickerKeybindings(g); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'A', "", ui.assignIssue); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", '<', "", ui.shrinkList); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", '>', "", ui.growList); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'z', "", ui.toggleZoom); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'v', "", ui.copyMode); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "details", 'v', "", ui.copyMode); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'U', "", ui.refreshSelected); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'u', "undo", ui.undoLast); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'R', "", ui.retryLoad); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", tui.KeyEsc, "", ui.dismissLoadError); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'X', "", ui.archiveDoneSubIssues); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "issues", 'P', "", ui.openPager); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "details", 'P', "", ui.openPager); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "search", tui.KeyEnter, "apply", ui.closeSearch); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "search", tui.KeyEsc, "cancel", ui.cancelSearch); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "comment", tui.KeyCtrlS, "submit", ui.submitComment); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "comment", tui.KeyCtrlE, "expand", ui.expandDraft); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "comment", tui.KeyCtrlQ, "cancel", ui.cancelComment); err != nil {
+		return nil, err
+	}
+	if err := ui.bind(g, "comment", tui.KeyEsc, "cancel", ui.cancelComment); err != nil {
+		return nil, err
+	}
+	// Plugins and scripts are loaded last so they cannot take keys of
+	// built-in actions
+	if err := ui.loadPlugins(g); err != nil {
+		return nil, err
+	}
+	if err := ui.bindScriptActions(g); err != nil {
 		return nil, err
 	}
 
 	return ui, nil
 }
 
+// initials returns the first letters of a name's first two words, or the
+// first two letters of a single-word name; "--" stands for unassigned
+func initials(name string) string {
+	parts := strings.Fields(name)
+	switch len(parts) {
+	case 0:
+		return "--"
+	case 1:
+		runes := []rune(parts[0])
+		if len(runes) > 2 {
+			runes = runes[:2]
+		}
+		return string(runes)
+	default:
+		return string([]rune(parts[0])[0]) + string([]rune(parts[1])[0])
+	}
+}
+
+// progressBarCells is the width of the sub-issue progress bar in list rows
+const progressBarCells = 4
+
+// progressBar renders sub-issue completion such as "▰▰▱▱ 2/5 " for issues
+// with children, and nothing for leaf issues
+func progressBar(issue api.Issue) string {
+	done, total := issue.Progress()
+	if total == 0 {
+		return ""
+	}
+	filled := done * progressBarCells / total
+	return fmt.Sprintf("%s%s %d/%d ", strings.Repeat("▰", filled), strings.Repeat("▱", progressBarCells-filled), done, total)
+}
+
+// projectPercent is the issue's project completion as a whole percentage
+func projectPercent(issue api.Issue) int {
+	return int(math.Round(issue.Project.Progress * 100))
+}
+
+// projectTag renders the issue's project name, cut to nameWidth display
+// columns, and its completion, e.g. "Billing v2 42%"
+func projectTag(issue api.Issue, nameWidth int) string {
+	if issue.Project.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %d%%", markdown.Truncate(issue.Project.Name, nameWidth), projectPercent(issue))
+}
+
+// renderIssue writes an issue's header, description, and comments to w,
+// wrapped to width
+func (ui *UI) renderIssue(w io.Writer, issue api.Issue, width int) {
+	fmt.Fprintf(w, "ID: %s\n", issue.ID)
+	fmt.Fprintf(w, "Title: %s\n", ui.text(issue.Title))
+	fmt.Fprintf(w, "State: %s\n", issue.State.Name)
+	if issue.Assignee.Name != "" {
+		fmt.Fprintf(w, "Assignee: %s\n", issue.Assignee.Name)
+	}
+	if issue.Project.Name != "" {
+		fmt.Fprintf(w, "Project: %s (%d%% complete)\n", ui.text(issue.Project.Name), projectPercent(issue))
+	}
+	ui.renderSubscribers(w, issue)
+	ui.renderRecurrence(w, issue)
+	ui.renderLifecycle(w, issue)
+	ui.renderAttachments(w, issue)
+	ui.renderRelations(w, issue)
+	ui.renderSummary(w, issue, width)
+	ui.renderNote(w, issue, width)
+	ui.renderCustomerNeeds(w, issue, width)
+	if !issue.Detailed {
+		fmt.Fprintln(w, "\nLoading details…")
+		ui.renderHistory(w, issue)
+		return
+	}
+	ui.renderDescription(w, issue, width)
+	ui.renderLinks(w, issue)
+	ui.renderComments(w, issue, width)
+	ui.renderHistory(w, issue)
+}
+
 // Run starts the UI main loop
 func (ui *UI) Run() error {
+	defer ui.restoreTerminalTitle()
 	defer ui.gui.Close()
+	go ui.autoRefresh()
+	ui.startPrefetch()
 	return ui.gui.MainLoop()
 }
 
+// startPrefetch starts fetching the other teams' issues in the background,
+// stopping the worker started before, if any
+func (ui *UI) startPrefetch() {
+	if ui.stopPrefetch != nil {
+		ui.stopPrefetch()
+		ui.stopPrefetch = nil
+	}
+	if teams := ui.store.Teams(); ui.client != nil && len(teams) > 1 && !ui.config.DisablePrefetch {
+		ctx, cancel := context.WithCancel(context.Background())
+		ui.stopPrefetch = cancel
+		go ui.prefetchTeams(ctx, ui.teamCache, append([]api.Team(nil), teams...))
+	}
+}
+
 // Close closes the UI
 func (ui *UI) Close() {
 	ui.gui.Close()
 }
 
-func (ui *UI) layout(g *gocui.Gui) error {
+func (ui *UI) layout(g *tui.Gui) error {
 	maxX, maxY := g.Size()
 
 	// Teams bar (top)
 	teamBarHeight := 2
 	if tv, err := g.SetView("teams", 0, 0, maxX-1, teamBarHeight); err != nil {
-		if err != gocui.ErrUnknownView {
+		if err != tui.ErrUnknownView {
 			return err
 		}
 		tv.Frame = true
 	}
 	if tv, err := g.View("teams"); err == nil {
 		tv.Clear()
-		if len(ui.teams) > 0 {
-			for i, team := range ui.teams {
-				if i == ui.currentTeam {
-					fmt.Fprintf(tv, "\033[32m%s\033[0m ", "[ "+team.Name+" ]")
+		if teams := ui.store.Teams(); len(teams) > 0 {
+			if ui.allTeams() {
+				label := fmt.Sprintf("[ All (%d/%d) ]", len(ui.issues), len(ui.store.All()))
+				fmt.Fprintf(tv, "%s ", colorize(ui.theme().activeTeam, label))
+			} else {
+				fmt.Fprint(tv, "All ")
+			}
+			for i, team := range teams {
+				if i == ui.store.TeamIndex() {
+					label := fmt.Sprintf("[ %s (%d/%d) ]", team.Name, len(ui.issues), len(ui.store.All()))
+					fmt.Fprintf(tv, "%s ", colorize(ui.theme().activeTeam, label))
+				} else if issues, _, ok := ui.teamCache.get(team.ID); ok {
+					fmt.Fprintf(tv, "%s (%d) ", team.Name, len(issues))
 				} else {
 					fmt.Fprintf(tv, "%s ", team.Name)
 				}
@@ -229,37 +690,48 @@ func (ui *UI) layout(g *gocui.Gui) error {
 		commentX := (maxX - commentWidth) / 2
 		commentY := (maxY - commentHeight) / 2
 
+		commentTitle := ui.composerTitle()
 		if cv, err := g.SetView("comment", commentX, commentY, commentX+commentWidth, commentY+commentHeight); err != nil {
-			if err != gocui.ErrUnknownView {
+			if err != tui.ErrUnknownView {
 				return err
 			}
-			cv.Title = "Add Comment (Ctrl+S to submit, Esc to cancel)"
+			cv.Title = commentTitle
 			cv.Editable = true
 			cv.Editor = &commentEditor{ui: ui}
 			cv.Wrap = true
+			if ui.commentContent != "" {
+				fmt.Fprint(cv, ui.commentContent)
+				lines := strings.Split(ui.commentContent, "\n")
+				cv.SetCursor(len(lines[len(lines)-1]), len(lines)-1)
+			}
 			g.SetCurrentView("comment")
 		} else {
-			cv.Title = "Add Comment (Ctrl+S to submit, Esc to cancel)"
+			cv.Title = commentTitle
 			g.SetCurrentView("comment")
 		}
-
+		if err := ui.layoutMentions(g, commentX+2, commentY+commentHeight, commentWidth/2); err != nil {
+			return err
+		}
 	} else {
+		ui.mention = nil
 		g.DeleteView("comment")
+		g.DeleteView("mentions")
 	}
 
 	// Search bar (if enabled)
 	if ui.showSearch {
 		if v, err := g.SetView("search", 0, maxY-4, maxX-1, maxY-2); err != nil {
-			if err != gocui.ErrUnknownView {
+			if err != tui.ErrUnknownView {
 				return err
 			}
-			v.Title = "Search (Enter to apply, Esc to cancel)"
+			v.Title = "Search (filters as you type, Enter to keep, Esc to clear)"
 			v.Editable = true
-			v.Editor = gocui.DefaultEditor
-			fmt.Fprint(v, ui.searchString)
-			v.SetCursor(len(ui.searchString), 0)
+			v.Editor = &searchEditor{ui: ui}
+			search := ui.store.Filter().Search
+			fmt.Fprint(v, search)
+			v.SetCursor(len(search), 0)
 		} else {
-			v.Title = "Search (Enter to apply, Esc to cancel)"
+			v.Title = "Search (filters as you type, Enter to keep, Esc to clear)"
 		}
 		g.SetCurrentView("search")
 	} else {
@@ -267,48 +739,55 @@ func (ui *UI) layout(g *gocui.Gui) error {
 	}
 
 	// Issues list (left side)
-	issuesX := int(0.4 * float32(maxX))
+	issuesX := maxX * ui.listWidth() / 100
 	bottomY := maxY - 3
 	if ui.showSearch {
 		bottomY = maxY - 5
 	}
 	v, err := g.SetView("issues", 0, teamBarHeight+1, issuesX, bottomY)
 	if err != nil {
-		if err != gocui.ErrUnknownView {
+		if err != tui.ErrUnknownView {
 			return err
 		}
 		v.Highlight = true
-		v.SelBgColor = gocui.ColorGreen
-		v.SelFgColor = gocui.ColorBlack
 	}
+	v.SelBgColor = ui.theme().selBg
+	v.SelFgColor = ui.theme().selFg
 
-	viewTitle := ui.views[ui.currentView]
-	if ui.assignedToMe {
+	filter := ui.store.Filter()
+	viewTitle := fmt.Sprintf("%s (%d/%d)", ui.views[ui.currentView], len(ui.issues), len(ui.store.All()))
+	if filter.AssignedToMe {
 		viewTitle = viewTitle + " (My Issues)"
 	}
-	if ui.searchString != "" {
-		viewTitle = viewTitle + " [" + ui.searchString + "]"
+	if filter.StartableOnly {
+		viewTitle = viewTitle + " (Startable)"
+	}
+	if filter.DueThisWeek {
+		viewTitle = viewTitle + " (Due this week)"
+	}
+	if filter.Overdue {
+		viewTitle = viewTitle + " (Overdue)"
+	}
+	if filter.Assignee != "" {
+		viewTitle = viewTitle + " (Assignee: " + ui.assigneeName(filter.Assignee) + ")"
+	}
+	if filter.Label != "" {
+		viewTitle = viewTitle + " (Label: " + filter.Label + ")"
+	}
+	if filter.Stalest {
+		viewTitle = viewTitle + " (Stalest first)"
+	}
+	if filter.Search != "" {
+		viewTitle = viewTitle + " [" + filter.Search + "]"
+	}
+	if age := time.Since(ui.lastRefresh); age >= ui.staleAfter() {
+		viewTitle = fmt.Sprintf("%s · data is %dm old — press r", viewTitle, int(age.Minutes()))
 	}
 	v.Title = viewTitle
+	v.Footer = listSummary(ui.issues)
 
 	// Update issues list
-	v.Clear()
-	for _, issue := range ui.issues {
-		initials := "--"
-		if issue.Assignee.Name != "" {
-			parts := strings.Fields(issue.Assignee.Name)
-			if len(parts) >= 2 {
-				initials = string(parts[0][0]) + string(parts[1][0])
-			} else if len(parts) == 1 {
-				if len(parts[0]) >= 2 {
-					initials = string(parts[0][0]) + string(parts[0][1])
-				} else {
-					initials = parts[0]
-				}
-			}
-		}
-		fmt.Fprintf(v, "\033[32m%s\033[0m \033[33m%s\033[0m %s\n", issue.Identifier, initials, issue.Title)
-	}
+	ui.drawList(v)
 
 	// Set cursor to first item if needed
 	if len(ui.issues) > 0 {
@@ -320,63 +799,152 @@ func (ui *UI) layout(g *gocui.Gui) error {
 		}
 	}
 
-	// Set focus to issues view (unless search or comment is active)
-	if !ui.showSearch && !ui.showComment {
-		g.SetCurrentView("issues")
+	// Set focus to issues view (unless search, comment, or settings is active)
+	if !ui.showSearch && !ui.showComment && !ui.showSettings && !ui.showInbox && !ui.showLabels && !ui.showMembers && ui.picker == nil && ui.prompt == nil && ui.popup == nil {
+		if ui.commentsFocused() {
+			g.SetCurrentView("details")
+		} else {
+			g.SetCurrentView("issues")
+		}
 	}
 
 	// Issue details (right side)
-	dv, err := g.SetView("details", issuesX+1, teamBarHeight+1, maxX-1, bottomY)
+	detailsX := issuesX + 1
+	if ui.config.ZoomDetails {
+		detailsX = 0
+	}
+	dv, err := g.SetView("details", detailsX, teamBarHeight+1, maxX-1, bottomY)
 	if err != nil {
-		if err != gocui.ErrUnknownView {
+		if err != tui.ErrUnknownView {
 			return err
 		}
 		dv.Title = "Issue Details"
 	}
 
 	// Update details content
-	dv.Clear()
-	if ui.showHelp {
-		fmt.Fprintln(dv, "LazyLinear Help")
-		fmt.Fprintln(dv, "===============")
-		fmt.Fprintln(dv, "")
-		fmt.Fprintln(dv, "Navigation:")
-		fmt.Fprintln(dv, "  j / ↓   : Move down")
-		fmt.Fprintln(dv, "  k / ↑   : Move up")
-		fmt.Fprintln(dv, "  [ / ]   : Switch view (All/In Review/In Progress/Blocked/Todo/Backlog)")
-		fmt.Fprintln(dv, "  { / }   : Switch team")
-		fmt.Fprintln(dv, "")
-		fmt.Fprintln(dv, "Actions:")
-		fmt.Fprintln(dv, "  Enter   : Select issue to view details")
-		fmt.Fprintln(dv, "  r       : Refresh issues")
-		fmt.Fprintln(dv, "  a       : Toggle filter by assigned to me")
-		fmt.Fprintln(dv, "  /       : Search issues (Enter to apply, Ctrl+Q to cancel)")
-		fmt.Fprintln(dv, "  c       : Add comment to selected issue")
-		fmt.Fprintln(dv, "  ,       : Copy issue URL to clipboard")
-		fmt.Fprintln(dv, "  .       : Copy git branch name to clipboard")
-		fmt.Fprintln(dv, "  h       : Toggle this help")
-		fmt.Fprintln(dv, "  Ctrl+C  : Quit")
-		fmt.Fprintln(dv, "")
-		fmt.Fprintln(dv, "Configuration:")
-		fmt.Fprintln(dv, "  Set your Linear API key in ~/.lazylinear/config.json")
-	} else if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
-		issue := ui.issues[ui.selectedIssue]
-		fmt.Fprintf(dv, "ID: %s\n", issue.ID)
-		fmt.Fprintf(dv, "Title: %s\n", issue.Title)
-		fmt.Fprintf(dv, "State: %s\n", issue.State.Name)
-		if issue.Assignee.Name != "" {
-			fmt.Fprintf(dv, "Assignee: %s\n", issue.Assignee.Name)
-		}
-		fmt.Fprintf(dv, "\nDescription:\n%s\n", issue.Description)
-		if len(issue.Comments.Nodes) > 0 {
-			fmt.Fprintln(dv, "\nComments:")
-			for _, comment := range issue.Comments.Nodes {
-				fmt.Fprintf(dv, "- %s (%s): %s\n", comment.User.Name, comment.CreatedAt, comment.Body)
+	ui.drawDetails(dv, func(w io.Writer, width int) {
+		if ui.showHelp {
+			fmt.Fprintln(w, "LazyLinear Help")
+			fmt.Fprintln(w, "===============")
+			fmt.Fprintln(w, "")
+			fmt.Fprintln(w, "Navigation:")
+			fmt.Fprintln(w, "  j / ↓   : Move down")
+			fmt.Fprintln(w, "  k / ↑   : Move up")
+			fmt.Fprintln(w, "  Space   : Collapse or expand sub-issues (← collapses or goes to parent, → expands)")
+			fmt.Fprintln(w, "  [ / ]   : Switch view (All/In Review/In Progress/Blocked/Todo/Backlog)")
+			fmt.Fprintln(w, "  1-9     : Jump to view tab (1=All, 2=In Review, ...)")
+			fmt.Fprintln(w, "  { / }   : Switch team, including All teams")
+			fmt.Fprintln(w, "  t       : Find team by name or key")
+			fmt.Fprintln(w, "  g       : Go to a blocking, blocked, duplicate, or related issue")
+			fmt.Fprintln(w, "  F       : Start or stop a focus timer on the issue (shown in the status bar)")
+			fmt.Fprintln(w, "  N       : Edit your local notes on the issue in $EDITOR")
+			fmt.Fprintln(w, "  T       : Summarize the issue and its comments with the configured LLM")
+			fmt.Fprintln(w, "  x       : Diff a description changed since you last viewed it")
+			fmt.Fprintln(w, "  f       : Open a link from the description or comments by its number")
+			fmt.Fprintln(w, "  Bksp    : Back to the issue a followed relation or link started from")
+			fmt.Fprintln(w, "  < / >   : Shrink / grow the issue list")
+			fmt.Fprintln(w, "  z       : Toggle full-screen details")
+			fmt.Fprintln(w, "  v       : Copy mode (show details as plain text for mouse selection)")
+			fmt.Fprintln(w, "  P       : Open the selected issue in $PAGER")
+			fmt.Fprintln(w, "")
+			fmt.Fprintln(w, "Actions:")
+			fmt.Fprintln(w, "  Enter   : Select issue to view details")
+			fmt.Fprintln(w, "  p       : Toggle preview-on-cursor (details follow the cursor)")
+			fmt.Fprintln(w, "  r       : Refresh issues")
+			fmt.Fprintln(w, "  U       : Refresh only the selected issue")
+			fmt.Fprintln(w, "  u       : Undo the last state change, assignment, or archive")
+			fmt.Fprintln(w, "  R / Esc : Retry / dismiss after a failed load")
+			fmt.Fprintln(w, "  a       : Toggle filter by assigned to me")
+			fmt.Fprintln(w, "  w       : Toggle startable work (unblocked Todo/Backlog, mine or unassigned)")
+			fmt.Fprintln(w, "  s       : Toggle sorting by staleness (least recently updated first)")
+			fmt.Fprintln(w, "  d / D   : Toggle issues due within 7 days / overdue (either when both)")
+			fmt.Fprintln(w, "  /       : Search identifiers, titles, and labels as you type (Enter to keep, Esc to clear)")
+			fmt.Fprintln(w, "  A       : Assign selected issue to a team member")
+			fmt.Fprintln(w, "  n       : Create a new issue in the current team (Ctrl+E expands the title with the LLM)")
+			fmt.Fprintln(w, "  X       : Archive the selected issue's done sub-issues (asks first)")
+			fmt.Fprintln(w, "  c       : Add comment to selected issue")
+			fmt.Fprintln(w, "  o       : Open issue in browser")
+			fmt.Fprintln(w, "  O       : Open every issue in the current filter (up to 10, asks first)")
+			fmt.Fprintln(w, "  ,       : Copy issue URL to clipboard")
+			fmt.Fprintln(w, "  .       : Copy git branch name to clipboard")
+			fmt.Fprintln(w, "  b       : Create and check out the issue's git branch")
+			fmt.Fprintln(w, "  G       : Create a GitHub pull request with gh, closing the issue")
+			fmt.Fprintln(w, "  C       : List issues referenced in recent git commits")
+			fmt.Fprintln(w, "  E       : Export the filtered list as Markdown or CSV")
+			fmt.Fprintln(w, "  V       : Export the issue as a markdown note to vault_dir")
+			fmt.Fprintln(w, "  W       : Subscribe a teammate to the issue")
+			fmt.Fprintln(w, "  K       : Send the issue to a Slack channel (slack_webhooks)")
+			fmt.Fprintln(w, "  L       : Open a linked pull request or attachment")
+			fmt.Fprintln(w, "  m       : Copy issue as a markdown link")
+			fmt.Fprintln(w, "  #       : Copy issue identifier")
+			fmt.Fprintln(w, "  Tab     : Select comments of the selected issue")
+			fmt.Fprintln(w, "  i       : Open notifications inbox (mentions and assignments first)")
+			fmt.Fprintln(w, "  l       : Browse labels; Enter filters, n creates, r renames")
+			fmt.Fprintln(w, "  M       : Team members with their active issues and points; Enter lists theirs")
+			for _, p := range ui.plugins {
+				fmt.Fprintf(w, "  %c       : %s (plugin)\n", p.key, p.description)
 			}
+			if ui.scripts != nil {
+				for _, action := range ui.scripts.Actions {
+					fmt.Fprintf(w, "  %c       : %s (script)\n", action.Key, action.Description)
+				}
+			}
+			fmt.Fprintln(w, "  S       : Open settings")
+			fmt.Fprintln(w, "  h       : Toggle this help")
+			fmt.Fprintln(w, "  Ctrl+C  : Quit")
+			fmt.Fprintln(w, "")
+			fmt.Fprintln(w, "Comments (after Tab):")
+			fmt.Fprintln(w, "  j / k   : Move between comments")
+			fmt.Fprintln(w, "  y       : Copy comment text")
+			fmt.Fprintln(w, "  l       : Copy comment permalink")
+			fmt.Fprintln(w, "  q       : Reply quoting the comment")
+			fmt.Fprintln(w, "  e       : Edit comment")
+			fmt.Fprintln(w, "  +       : Add or remove a reaction")
+			fmt.Fprintln(w, "  r       : Reply in the comment's thread")
+			fmt.Fprintln(w, "  Enter   : Collapse or expand the thread")
+			fmt.Fprintln(w, "  o       : Open comment author in browser")
+			fmt.Fprintln(w, "  v       : Copy mode")
+			fmt.Fprintln(w, "  Esc/Tab : Back to issue list")
+			fmt.Fprintln(w, "")
+			fmt.Fprintln(w, "Configuration:")
+			fmt.Fprintln(w, "  Set your Linear API key with S, or in ~/.lazylinear/config.json")
+		} else if ui.unauthorized() {
+			fmt.Fprintln(w, "API key invalid or missing — press S to configure")
+			fmt.Fprintln(w, "")
+			fmt.Fprintln(w, "Create a personal API key in Linear under Settings → Security & access,")
+			fmt.Fprintln(w, "then choose \"API key\" on the settings screen and paste it in.")
+		} else if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+			ui.renderIssue(w, ui.issues[ui.selectedIssue], width)
+		} else {
+			fmt.Fprintln(w, "Select an issue to view details")
+			fmt.Fprintln(w, "Press 'h' for help")
 		}
-	} else {
-		fmt.Fprintln(dv, "Select an issue to view details")
-		fmt.Fprintln(dv, "Press 'h' for help")
+	})
+	ui.scrollToSelectedComment(dv)
+
+	if err := ui.layoutErrorBanner(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutInbox(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutLabels(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutMembers(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutSettings(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutPicker(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutPrompt(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := ui.layoutPopup(g, maxX, maxY); err != nil {
+		return err
 	}
 
 	// Status bar (bottom)
@@ -385,37 +953,52 @@ func (ui *UI) layout(g *gocui.Gui) error {
 		statusY = maxY - 1
 	}
 	if v, err := g.SetView("status", 0, statusY, maxX-1, maxY); err != nil {
-		if err != gocui.ErrUnknownView {
+		if err != tui.ErrUnknownView {
 			return err
 		}
 		v.Frame = false
 	}
 	if sv, err := g.View("status"); err == nil {
 		sv.Clear()
-		status := "j/k/↑/↓: navigate | [/]: switch view | Enter: select | r: refresh | /: search | a: my issues | h: help | Ctrl+C: quit"
-		if ui.assignedToMe {
+		focused := "issues"
+		if cv := g.CurrentView(); cv != nil {
+			focused = cv.Name()
+		}
+		status := ui.statusHints(focused)
+		if filter.AssignedToMe {
 			status = "[My Issues] " + status
 		}
-		if ui.searchString != "" {
-			status = fmt.Sprintf("[Search: %s] %s", ui.searchString, status)
+		status = ui.focusStatus() + status
+		if filter.Search != "" {
+			status = fmt.Sprintf("[Search: %s] %s", filter.Search, status)
+		}
+		if time.Now().Before(ui.flashUntil) {
+			status = "\033[7m" + status + "\033[0m"
+		}
+		if t := ui.activeToast(); t != nil {
+			color := ui.theme().toast
+			if t.failed {
+				color = ui.theme().toastError
+			}
+			status = colorize(color, t.message)
 		}
-		fmt.Fprintln(sv, status)
+		fmt.Fprintln(sv, markdown.Truncate(status, maxX))
 	}
 
 	return nil
 }
 
-func (ui *UI) quit(g *gocui.Gui, v *gocui.View) error {
-	return gocui.ErrQuit
+func (ui *UI) quit(g *tui.Gui, v *tui.View) error {
+	return tui.ErrQuit
 }
 
-func (ui *UI) cursorDown(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) cursorDown(g *tui.Gui, v *tui.View) error {
 	if v != nil && len(ui.issues) > 0 {
 		cx, cy := v.Cursor()
 		ox, oy := v.Origin()
 		_, maxY := v.Size()
 
-		if cy < len(ui.issues)-1 {
+		if cursorIndex(v) < len(ui.issues)-1 {
 			if err := v.SetCursor(cx, cy+1); err != nil {
 				if cy+1 >= maxY-1 {
 					if err := v.SetOrigin(ox, oy+1); err != nil {
@@ -424,11 +1007,12 @@ func (ui *UI) cursorDown(g *gocui.Gui, v *gocui.View) error {
 				}
 			}
 		}
+		ui.previewCursor(v)
 	}
 	return nil
 }
 
-func (ui *UI) cursorUp(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) cursorUp(g *tui.Gui, v *tui.View) error {
 	if v != nil && len(ui.issues) > 0 {
 		cx, cy := v.Cursor()
 		ox, oy := v.Origin()
@@ -442,48 +1026,143 @@ func (ui *UI) cursorUp(g *gocui.Gui, v *gocui.View) error {
 				return err
 			}
 		}
+		ui.previewCursor(v)
 	}
 	return nil
 }
 
-func (ui *UI) refreshIssues(g *gocui.Gui, v *gocui.View) error {
-	if ui.client != nil {
-		teamID := ""
-		if ui.currentTeam >= 0 && ui.currentTeam < len(ui.teams) {
-			teamID = ui.teams[ui.currentTeam].ID
-		}
-		if fetchedIssues, err := ui.client.GetIssues(context.Background(), teamID); err == nil {
-			ui.allIssues = fetchedIssues
-		} else {
-			ui.allIssues = []api.Issue{{Title: fmt.Sprintf("Error loading issues: %v", err)}}
-		}
+// staleAfter returns the age at which loaded issues are flagged as stale
+func (ui *UI) staleAfter() time.Duration {
+	if ui.config.StaleAfter > 0 {
+		return time.Duration(ui.config.StaleAfter) * time.Minute
 	}
-	ui.issues = ui.filterIssues()
-	ui.selectedIssue = -1
-	return nil
+	return 10 * time.Minute
 }
 
-func (ui *UI) selectIssue(g *gocui.Gui, v *gocui.View) error {
+// cursorIndex returns the line index under the cursor, accounting for scrolling
+func cursorIndex(v *tui.View) int {
+	_, oy := v.Origin()
 	_, cy := v.Cursor()
-	if cy >= 0 && cy < len(ui.issues) {
-		ui.selectedIssue = cy
+	return oy + cy
+}
+
+// previewCursor selects the issue under the cursor when preview-on-cursor is enabled
+func (ui *UI) previewCursor(v *tui.View) {
+	if !ui.config.PreviewOnCursor {
+		return
+	}
+	if i := cursorIndex(v); i >= 0 && i < len(ui.issues) {
+		ui.selectRow(i)
+	}
+}
+
+func (ui *UI) refreshIssues(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil {
+		ui.resetIssueCaches()
+		return nil
+	}
+	ui.applyIssues(ui.fetchIssues())
+	return nil
+}
+
+// fetchIssues fetches the selected team's issues, or every team's. It only
+// touches the store and team cache, so it may run off the UI goroutine.
+func (ui *UI) fetchIssues() ([]api.Issue, error) {
+	if ui.allTeams() {
+		return ui.fetchAllTeams(true)
+	}
+	teamID := ""
+	if team, ok := ui.store.CurrentTeam(); ok {
+		teamID = team.ID
+	}
+	issues, err := ui.client.GetIssues(context.Background(), teamID)
+	if err == nil {
+		ui.teamCache.store(teamID, issues, true)
+	}
+	return issues, err
+}
+
+// applyIssues shows the result of fetchIssues and resets the per-issue caches
+func (ui *UI) applyIssues(issues []api.Issue, err error) {
+	if err != nil {
+		ui.loadFailed(err)
+		return
+	}
+	ui.loadErr = nil
+	ui.resetIssueCaches()
+	ui.store.SetIssues(issues)
+	ui.listedTeam = ui.store.TeamIndex()
+	ui.hookRefresh()
+}
+
+// resetIssueCaches marks the issues as just refreshed and drops the
+// details, history and customer needs loaded for them
+func (ui *UI) resetIssueCaches() {
+	ui.lastRefresh = time.Now()
+	ui.stateSince = make(map[string]time.Time)
+	ui.detailsRequested = make(map[string]bool)
+	ui.history = make(map[string][]api.HistoryEntry)
+	ui.customerNeeds = make(map[string][]api.CustomerNeed)
+}
+
+// refreshSelected refetches just the selected issue, details and comments
+// included, without reloading the whole team list
+func (ui *UI) refreshSelected(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil || ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	issue, err := ui.reloadSelected()
+	if err != nil {
+		ui.notifyError("Refresh", err)
+		return nil
+	}
+	ui.notify("Refreshed %s", issue.Identifier)
+	return nil
+}
+
+// reloadSelected refetches the selected issue and replaces it in place
+func (ui *UI) reloadSelected() (*api.Issue, error) {
+	issue, err := ui.client.GetIssue(context.Background(), ui.issues[ui.selectedIssue].ID)
+	if err != nil {
+		return nil, err
+	}
+	// Dropped first, so the reload below fetches them again
+	delete(ui.stateSince, issue.ID)
+	delete(ui.history, issue.ID)
+	delete(ui.customerNeeds, issue.ID)
+	ui.store.UpsertIssue(*issue)
+	return issue, nil
+}
+
+func (ui *UI) selectIssue(g *tui.Gui, v *tui.View) error {
+	if i := cursorIndex(v); i >= 0 && i < len(ui.issues) {
+		ui.selectRow(i)
 	}
 	return nil
 }
 
-func (ui *UI) toggleHelp(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) toggleHelp(g *tui.Gui, v *tui.View) error {
 	ui.showHelp = !ui.showHelp
 	return nil
 }
 
-func (ui *UI) toggleAssigned(g *gocui.Gui, v *gocui.View) error {
-	ui.assignedToMe = !ui.assignedToMe
-	ui.issues = ui.filterIssues()
-	ui.selectedIssue = -1
+func (ui *UI) toggleAssigned(g *tui.Gui, v *tui.View) error {
+	ui.store.UpdateFilter(func(f *store.Filter) { f.AssignedToMe = !f.AssignedToMe })
+	return nil
+}
+
+func (ui *UI) togglePreview(g *tui.Gui, v *tui.View) error {
+	ui.config.PreviewOnCursor = !ui.config.PreviewOnCursor
+	ui.previewCursor(v)
 	return nil
 }
 
-func (ui *UI) toggleSearch(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) toggleStartable(g *tui.Gui, v *tui.View) error {
+	ui.store.UpdateFilter(func(f *store.Filter) { f.StartableOnly = !f.StartableOnly })
+	return nil
+}
+
+func (ui *UI) toggleSearch(g *tui.Gui, v *tui.View) error {
 	ui.showSearch = !ui.showSearch
 	if ui.showSearch {
 		g.SetCurrentView("search")
@@ -491,31 +1170,28 @@ func (ui *UI) toggleSearch(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
-func (ui *UI) closeSearch(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) closeSearch(g *tui.Gui, v *tui.View) error {
 	if v != nil {
-		ui.searchString = strings.TrimSpace(v.Buffer())
-		ui.issues = ui.filterIssues()
-		ui.selectedIssue = -1
+		search := strings.TrimSpace(v.Buffer())
+		ui.store.UpdateFilter(func(f *store.Filter) { f.Search = search })
 	}
 	ui.showSearch = false
 	g.SetCurrentView("issues")
 	return nil
 }
 
-func (ui *UI) cancelSearch(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) cancelSearch(g *tui.Gui, v *tui.View) error {
 	if v != nil {
 		v.Clear()
 		v.SetCursor(0, 0)
 	}
-	ui.searchString = ""
-	ui.issues = ui.filterIssues()
-	ui.selectedIssue = -1
+	ui.store.UpdateFilter(func(f *store.Filter) { f.Search = "" })
 	ui.showSearch = false
 	g.SetCurrentView("issues")
 	return nil
 }
 
-func (ui *UI) toggleComment(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) toggleComment(g *tui.Gui, v *tui.View) error {
 	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
 		ui.showComment = true
 		ui.commentContent = ""
@@ -523,16 +1199,28 @@ func (ui *UI) toggleComment(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
-func (ui *UI) submitComment(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) submitComment(g *tui.Gui, v *tui.View) error {
+	if ui.creatingIssue && v != nil {
+		return ui.submitIssue(g, v)
+	}
 	if v != nil && ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
 		comment := strings.TrimSpace(v.Buffer())
 		if comment != "" && ui.client != nil {
 			issue := ui.issues[ui.selectedIssue]
-			if err := ui.client.AddComment(context.Background(), issue.ID, comment); err != nil {
-				// TODO: Show error to user
+			var err error
+			if ui.editingCommentID != "" {
+				err = ui.client.UpdateComment(context.Background(), ui.editingCommentID, comment)
+			} else if ui.replyToID != "" {
+				err = ui.client.AddReply(context.Background(), issue.ID, ui.replyToID, comment)
+			} else {
+				err = ui.client.AddComment(context.Background(), issue.ID, comment)
+			}
+			if err != nil {
+				ui.notifyError("Saving comment", err)
 			} else {
 				// Refresh to show new comment
 				ui.refreshIssues(g, v)
+				ui.notify("Comment saved on %s", issue.Identifier)
 			}
 		}
 		v.Clear()
@@ -540,130 +1228,239 @@ func (ui *UI) submitComment(g *gocui.Gui, v *gocui.View) error {
 	}
 	ui.showComment = false
 	ui.commentContent = ""
+	ui.editingCommentID = ""
+	ui.replyToID = ""
 	g.SetCurrentView("issues")
 	return nil
 }
 
-func (ui *UI) cancelComment(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) cancelComment(g *tui.Gui, v *tui.View) error {
+	if ui.mention != nil {
+		// Esc closes the mention autocomplete before the composer
+		ui.mention = nil
+		return nil
+	}
 	if v != nil {
 		v.Clear()
 		v.SetCursor(0, 0)
 	}
 	ui.showComment = false
 	ui.commentContent = ""
+	ui.editingCommentID = ""
+	ui.replyToID = ""
+	ui.creatingIssue = false
+	ui.lintWarnings = nil
 	g.SetCurrentView("issues")
 	return nil
 }
 
-func (ui *UI) prevView(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) prevView(g *tui.Gui, v *tui.View) error {
 	ui.currentView--
 	if ui.currentView < 0 {
 		ui.currentView = len(ui.views) - 1
 	}
-	ui.issues = ui.filterIssues()
-	ui.selectedIssue = -1
+	ui.applyView()
 	return nil
 }
 
-func (ui *UI) nextView(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) nextView(g *tui.Gui, v *tui.View) error {
 	ui.currentView++
 	if ui.currentView >= len(ui.views) {
 		ui.currentView = 0
 	}
-	ui.issues = ui.filterIssues()
-	ui.selectedIssue = -1
+	ui.applyView()
 	return nil
 }
 
-func (ui *UI) prevTeam(g *gocui.Gui, v *gocui.View) error {
-	if len(ui.teams) == 0 {
+// jumpToView returns a handler that selects view tab i, ignoring tabs that
+// do not exist
+func (ui *UI) jumpToView(i int) func(*tui.Gui, *tui.View) error {
+	return func(g *tui.Gui, v *tui.View) error {
+		if i >= len(ui.views) || i == ui.currentView {
+			return nil
+		}
+		ui.currentView = i
+		ui.applyView()
 		return nil
 	}
-	ui.currentTeam--
-	if ui.currentTeam < 0 {
-		ui.currentTeam = len(ui.teams) - 1
-	}
-	return ui.refreshIssues(g, v)
 }
 
-func (ui *UI) nextTeam(g *gocui.Gui, v *gocui.View) error {
-	if len(ui.teams) == 0 {
+// applyView filters the list to the selected view tab
+func (ui *UI) applyView() {
+	view := ui.views[ui.currentView]
+	ui.store.UpdateFilter(func(f *store.Filter) { f.View = view })
+}
+
+// prevTeam and nextTeam cycle through the team bar, All teams first
+func (ui *UI) prevTeam(g *tui.Gui, v *tui.View) error {
+	return ui.cycleTeam(g, v, -1)
+}
+
+func (ui *UI) nextTeam(g *tui.Gui, v *tui.View) error {
+	return ui.cycleTeam(g, v, 1)
+}
+
+func (ui *UI) cycleTeam(g *tui.Gui, v *tui.View, delta int) error {
+	n := len(ui.store.Teams())
+	if n == 0 {
 		return nil
 	}
-	ui.currentTeam++
-	if ui.currentTeam >= len(ui.teams) {
-		ui.currentTeam = 0
+	// Shift indexes so AllTeams is slot 0 of n+1
+	slot := (ui.store.TeamIndex() - store.AllTeams + delta + n + 1) % (n + 1)
+	ui.store.SelectTeam(slot + store.AllTeams)
+	return ui.loadTeam(g, v)
+}
+
+func (ui *UI) copyURL(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+		issue := ui.issues[ui.selectedIssue]
+		if issue.URL != "" {
+			return ui.copyAndNotify("URL", issue.URL)
+		}
 	}
-	return ui.refreshIssues(g, v)
+	return nil
 }
 
-func (ui *UI) copyURL(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) copyBranch(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+		issue := ui.issues[ui.selectedIssue]
+		if branch := ui.branchName(issue); branch != "" {
+			return ui.copyAndNotify("branch name", branch)
+		}
+	}
+	return nil
+}
+
+func (ui *UI) copyMarkdownLink(g *tui.Gui, v *tui.View) error {
 	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
 		issue := ui.issues[ui.selectedIssue]
 		if issue.URL != "" {
-			return ui.copyToClipboard(issue.URL)
+			return ui.copyAndNotify("markdown link", fmt.Sprintf("[%s: %s](%s)", issue.Identifier, issue.Title, issue.URL))
 		}
 	}
 	return nil
 }
 
-func (ui *UI) copyBranch(g *gocui.Gui, v *gocui.View) error {
+func (ui *UI) copyIdentifier(g *tui.Gui, v *tui.View) error {
 	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
 		issue := ui.issues[ui.selectedIssue]
-		if issue.BranchName != "" {
-			return ui.copyToClipboard(issue.BranchName)
+		if issue.Identifier != "" {
+			return ui.copyAndNotify(issue.Identifier, issue.Identifier)
 		}
 	}
 	return nil
 }
 
-func (ui *UI) copyToClipboard(text string) error {
-	cmd := exec.Command("xclip", "-selection", "clipboard")
-	if _, err := exec.LookPath("xclip"); err != nil {
-		cmd = exec.Command("xsel", "--clipboard", "--input")
-		if _, err := exec.LookPath("xsel"); err != nil {
-			cmd = exec.Command("wl-copy")
-			if _, err := exec.LookPath("wl-copy"); err != nil {
-				cmd = exec.Command("pbcopy")
+// copyAndNotify copies text to the clipboard and reports the result in the status bar
+func (ui *UI) copyAndNotify(what, text string) error {
+	if err := clipboard.Copy(ui.config.ClipboardCommand, text); err != nil {
+		ui.notifyError("Copy", err)
+		return nil
+	}
+	ui.notify("Copied %s", what)
+	return nil
+}
+
+// filterTeams applies the configured team allowlist and hidden teams
+func filterTeams(teams []api.Team, cfg *config.Config) []api.Team {
+	matches := func(team api.Team, refs []string) bool {
+		for _, ref := range refs {
+			if strings.EqualFold(team.Key, ref) || strings.EqualFold(team.Name, ref) {
+				return true
 			}
 		}
+		return false
 	}
 
-	in, err := cmd.StdinPipe()
-	if err != nil {
-		return err
+	var filtered []api.Team
+	for _, team := range teams {
+		if len(cfg.Teams) > 0 && !matches(team, cfg.Teams) {
+			continue
+		}
+		if matches(team, cfg.HiddenTeams) {
+			continue
+		}
+		filtered = append(filtered, team)
 	}
+	return filtered
+}
 
-	if err := cmd.Start(); err != nil {
-		return err
+// findTeam returns the index of the team matching key, name, or ID,
+// falling back to the first team
+func findTeam(teams []api.Team, ref string) int {
+	if ref == "" {
+		return 0
+	}
+	for i, team := range teams {
+		if strings.EqualFold(team.Key, ref) || strings.EqualFold(team.Name, ref) || team.ID == ref {
+			return i
+		}
 	}
+	return 0
+}
 
-	if _, err := in.Write([]byte(text)); err != nil {
-		return err
+// text prepares API-provided text for display, rendering emoji shortcodes
+func (ui *UI) text(s string) string {
+	if ui.config.PlainEmoji {
+		return s
 	}
+	return emoji.Render(s)
+}
 
-	if err := in.Close(); err != nil {
-		return err
-	}
+// applyTheme sets the gui-level colors of the active theme
+func (ui *UI) applyTheme() {
+	ui.gui.SelFgColor = ui.theme().border // Active pane border color
+}
 
-	return cmd.Wait()
+// startable reports whether an issue is actionable now: in Todo or Backlog,
+// assigned to the viewer or unassigned, and not blocked by unresolved issues
+func (ui *UI) startable(issue api.Issue) bool {
+	return ui.store.Startable(issue)
 }
 
-func (ui *UI) filterIssues() []api.Issue {
-	var filtered []api.Issue
-	currentViewName := ui.views[ui.currentView]
+// stateName returns the canonical state of an issue, honoring configured aliases
+func (ui *UI) stateName(issue api.Issue) string {
+	return ui.store.StateName(issue)
+}
 
-	for _, issue := range ui.allIssues {
-		if ui.assignedToMe && issue.Assignee.ID != ui.viewerID {
-			continue
-		}
-		if currentViewName != "All" && issue.State.Name != currentViewName {
-			continue
+// openURL opens url in the default browser, copying it to the clipboard
+// instead when no browser opener is available
+func (ui *UI) openURL(url string) error {
+	cmd := browserCommand(url)
+	if cmd == nil {
+		return ui.copyAndNotify("URL (no browser opener found)", url)
+	}
+	if err := cmd.Start(); err != nil {
+		ui.notifyError("Opening browser", err)
+		return nil
+	}
+	go cmd.Wait()
+	ui.notify("Opened %s", url)
+	return nil
+}
+
+// browserCommand returns the platform command that opens url, or nil if none is installed
+func browserCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	for _, opener := range []string{"xdg-open", "wslview", "open"} {
+		if _, err := exec.LookPath(opener); err == nil {
+			return exec.Command(opener, url)
 		}
-		if ui.searchString != "" && !strings.Contains(strings.ToLower(issue.Title), strings.ToLower(ui.searchString)) {
-			continue
+	}
+	return nil
+}
+
+// openIssue opens the selected issue in the browser
+func (ui *UI) openIssue(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue >= 0 && ui.selectedIssue < len(ui.issues) {
+		if url := ui.issues[ui.selectedIssue].URL; url != "" {
+			return ui.openURL(url)
 		}
-		filtered = append(filtered, issue)
 	}
-	return filtered
+	return nil
 }
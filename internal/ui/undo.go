@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"context"
+
+	"lazylinear/internal/tui"
+)
+
+// maxUndo is how many mutations u can step back through
+const maxUndo = 20
+
+// undoEntry reverses one mutation
+type undoEntry struct {
+	// description completes "Undid ...", e.g. "assignment of ENG-123"
+	description string
+	undo        func(ctx context.Context) error
+}
+
+// pushUndo records how to reverse a mutation that just succeeded
+func (ui *UI) pushUndo(description string, undo func(ctx context.Context) error) {
+	ui.undoStack = append(ui.undoStack, undoEntry{description: description, undo: undo})
+	if len(ui.undoStack) > maxUndo {
+		ui.undoStack = ui.undoStack[len(ui.undoStack)-maxUndo:]
+	}
+}
+
+// undoLast reverses the most recent mutation and reloads the list
+func (ui *UI) undoLast(g *tui.Gui, v *tui.View) error {
+	if ui.client == nil || len(ui.undoStack) == 0 {
+		ui.notify("Nothing to undo")
+		return nil
+	}
+	entry := ui.undoStack[len(ui.undoStack)-1]
+	if err := entry.undo(context.Background()); err != nil {
+		// Leave the entry in place so the undo can be retried
+		ui.notifyError("Undoing "+entry.description, err)
+		return nil
+	}
+	ui.undoStack = ui.undoStack[:len(ui.undoStack)-1]
+	if err := ui.refreshIssues(g, v); err != nil {
+		return err
+	}
+	ui.notify("Undid %s", entry.description)
+	return nil
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"lazylinear/internal/export"
+	"lazylinear/internal/tui"
+)
+
+// expandHome resolves a leading "~/" in a configured path
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// exportToVault writes the selected issue, description and comments
+// included, to <vault_dir>/<identifier>.md, replacing an earlier export
+func (ui *UI) exportToVault(g *tui.Gui, v *tui.View) error {
+	if ui.selectedIssue < 0 || ui.selectedIssue >= len(ui.issues) {
+		return nil
+	}
+	if ui.config.VaultDir == "" {
+		ui.notify("Add vault_dir to ~/.lazylinear/config.json to export issues as notes")
+		return nil
+	}
+	issue := ui.issues[ui.selectedIssue]
+	if !issue.Detailed && ui.client != nil {
+		full, err := ui.client.GetIssue(context.Background(), issue.ID)
+		if err != nil {
+			ui.notifyError("Exporting "+issue.Identifier, err)
+			return nil
+		}
+		issue = *full
+	}
+
+	dir := expandHome(ui.config.VaultDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		ui.notifyError("Exporting "+issue.Identifier, err)
+		return nil
+	}
+	path := filepath.Join(dir, issue.Identifier+".md")
+	file, err := os.Create(path)
+	if err != nil {
+		ui.notifyError("Exporting "+issue.Identifier, err)
+		return nil
+	}
+	defer file.Close()
+	if err := export.Note(file, issue); err != nil {
+		ui.notifyError("Exporting "+issue.Identifier, err)
+		return nil
+	}
+	ui.notify("Exported %s to %s", issue.Identifier, path)
+	return nil
+}
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
 
 	"lazylinear/internal/api"
+	"lazylinear/internal/cli"
 	"lazylinear/internal/config"
 	"lazylinear/internal/ui"
 )
@@ -11,18 +17,90 @@ import (
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
-		log.Printf("Warning: could not load config: %v", err)
+		log.Printf("Warning: could not load config: %v (saving settings will move it to config.json.bak)", err)
 		cfg = &config.Config{}
 	}
 
 	client := api.NewClient(cfg.APIKey)
+	client.SetStateAliases(cfg.StateAliases)
+	if cfg.APIURL != "" {
+		client.SetEndpoint(cfg.APIURL)
+	}
+	if cfg.CAFile != "" {
+		err := client.ConfigureTLS(api.TLSOptions{CAFile: cfg.CAFile})
+		if err != nil {
+			log.Fatalf("configuring TLS: %v", err)
+		}
+	}
+
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], client, cfg))
+	}
+
+	// `lazylinear ENG-123` opens the TUI on that issue
+	args := os.Args[1:]
+	var focus string
+	if len(args) > 0 && ui.IsIdentifier(args[0]) {
+		focus, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("lazylinear", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: lazylinear [ISSUE-ID] [flags]\n       lazylinear <command> [flags]")
+		fs.PrintDefaults()
+	}
+	team := fs.String("team", "", "start on this team (key, name, or ID)")
+	assignee := fs.String("assignee", "", `start filtered to an assignee; only "me" is supported`)
+	view := fs.String("view", "", `start on this view tab, e.g. "In Review"`)
+	debug := fs.Bool("debug", false, "log API requests, GraphQL errors, and UI events to ~/.lazylinear/debug.log")
+	fs.Parse(args)
+	if *assignee != "" && *assignee != "me" {
+		fmt.Fprintf(os.Stderr, "unsupported --assignee %q: only \"me\" is supported\n", *assignee)
+		os.Exit(2)
+	}
+	if focus == "" && fs.NArg() > 0 && ui.IsIdentifier(fs.Arg(0)) {
+		focus = fs.Arg(0)
+	}
+
+	var logger *slog.Logger
+	if *debug {
+		logFile, err := openDebugLog()
+		if err != nil {
+			log.Fatalf("opening debug log: %v", err)
+		}
+		defer logFile.Close()
+		logger = slog.New(slog.NewTextHandler(logFile, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client.SetLogger(logger)
+		logger.Info("starting", "args", os.Args[1:])
+	}
 
-	ui, err := ui.NewUI(client)
+	ui, err := ui.NewUI(client, cfg, ui.Options{
+		Team:         *team,
+		AssignedToMe: *assignee == "me",
+		View:         *view,
+		Logger:       logger,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
+	if focus != "" {
+		ui.FocusIssue(focus)
+	}
 
 	if err := ui.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// openDebugLog opens ~/.lazylinear/debug.log for appending
+func openDebugLog() (*os.File, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(home, ".lazylinear")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filepath.Join(dir, "debug.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+}